@@ -20,18 +20,117 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Scheme describes how the merkle tree is built. An empty scheme selects the defaults
+// (SHA-256 without domain separation, hexadecimal node encoding, midpoint split).
+type Scheme struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HashAlgorithm string `protobuf:"bytes,1,opt,name=hash_algorithm,json=hashAlgorithm,proto3" json:"hash_algorithm,omitempty"`
+	// Prefix leaf hashes with 0x00 and interior node hashes with 0x01 (RFC 6962)
+	DomainSeparation bool `protobuf:"varint,2,opt,name=domain_separation,json=domainSeparation,proto3" json:"domain_separation,omitempty"`
+	// 1: interior nodes hash the hexadecimal child digests, 2: interior nodes hash the raw child digests
+	Version int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// 1: split the leaves of a node at the midpoint, 2: split them like RFC 6962 (largest power of two to the left),
+	// 3: store the tree as a merkle mountain range, whose root hash equals the one of 2,
+	// 4: split like 2 but duplicate the last node of every level with an odd number of nodes (Bitcoin)
+	Shape int32 `protobuf:"varint,4,opt,name=shape,proto3" json:"shape,omitempty"`
+	// Split files into chunks of this many bytes, each file's leaf is the root of a sub-tree over its chunks. 0 disables chunking
+	ChunkSize int64 `protobuf:"varint,5,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// 1: chunks of exactly chunk_size bytes, 2: content-defined chunks of chunk_size bytes on average (FastCDC)
+	Chunking int32 `protobuf:"varint,6,opt,name=chunking,proto3" json:"chunking,omitempty"`
+}
+
+func (x *Scheme) Reset() {
+	*x = Scheme{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scheme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scheme) ProtoMessage() {}
+
+func (x *Scheme) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scheme.ProtoReflect.Descriptor instead.
+func (*Scheme) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scheme) GetHashAlgorithm() string {
+	if x != nil {
+		return x.HashAlgorithm
+	}
+	return ""
+}
+
+func (x *Scheme) GetDomainSeparation() bool {
+	if x != nil {
+		return x.DomainSeparation
+	}
+	return false
+}
+
+func (x *Scheme) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Scheme) GetShape() int32 {
+	if x != nil {
+		return x.Shape
+	}
+	return 0
+}
+
+func (x *Scheme) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *Scheme) GetChunking() int32 {
+	if x != nil {
+		return x.Chunking
+	}
+	return 0
+}
+
 type UploadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Files [][]byte `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Files  [][]byte `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Scheme *Scheme  `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Optional file names, one per file. If set, the files are also keyed in a sparse merkle tree
+	FileNames []string `protobuf:"bytes,3,rep,name=file_names,json=fileNames,proto3" json:"file_names,omitempty"`
 }
 
 func (x *UploadRequest) Reset() {
 	*x = UploadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[0]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -44,7 +143,7 @@ func (x *UploadRequest) String() string {
 func (*UploadRequest) ProtoMessage() {}
 
 func (x *UploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[0]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +156,7 @@ func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
 func (*UploadRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{0}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *UploadRequest) GetFiles() [][]byte {
@@ -67,18 +166,35 @@ func (x *UploadRequest) GetFiles() [][]byte {
 	return nil
 }
 
+func (x *UploadRequest) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *UploadRequest) GetFileNames() []string {
+	if x != nil {
+		return x.FileNames
+	}
+	return nil
+}
+
 type UploadResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MerkleRootHash []byte `protobuf:"bytes,1,opt,name=merkle_root_hash,json=merkleRootHash,proto3" json:"merkle_root_hash,omitempty"`
+	MerkleRootHash []byte  `protobuf:"bytes,1,opt,name=merkle_root_hash,json=merkleRootHash,proto3" json:"merkle_root_hash,omitempty"`
+	Scheme         *Scheme `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Root hash of the sparse merkle tree, empty if the files were uploaded without file names
+	SparseRootHash []byte `protobuf:"bytes,3,opt,name=sparse_root_hash,json=sparseRootHash,proto3" json:"sparse_root_hash,omitempty"`
 }
 
 func (x *UploadResponse) Reset() {
 	*x = UploadResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[1]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -91,7 +207,7 @@ func (x *UploadResponse) String() string {
 func (*UploadResponse) ProtoMessage() {}
 
 func (x *UploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[1]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -104,7 +220,7 @@ func (x *UploadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
 func (*UploadResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{1}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *UploadResponse) GetMerkleRootHash() []byte {
@@ -114,6 +230,20 @@ func (x *UploadResponse) GetMerkleRootHash() []byte {
 	return nil
 }
 
+func (x *UploadResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *UploadResponse) GetSparseRootHash() []byte {
+	if x != nil {
+		return x.SparseRootHash
+	}
+	return nil
+}
+
 type DownloadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -125,7 +255,7 @@ type DownloadRequest struct {
 func (x *DownloadRequest) Reset() {
 	*x = DownloadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[2]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -138,7 +268,7 @@ func (x *DownloadRequest) String() string {
 func (*DownloadRequest) ProtoMessage() {}
 
 func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[2]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,7 +281,7 @@ func (x *DownloadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadRequest.ProtoReflect.Descriptor instead.
 func (*DownloadRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DownloadRequest) GetFileIndex() int64 {
@@ -172,7 +302,7 @@ type DownloadResponse struct {
 func (x *DownloadResponse) Reset() {
 	*x = DownloadResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[3]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -185,7 +315,7 @@ func (x *DownloadResponse) String() string {
 func (*DownloadResponse) ProtoMessage() {}
 
 func (x *DownloadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[3]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +328,7 @@ func (x *DownloadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadResponse.ProtoReflect.Descriptor instead.
 func (*DownloadResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{3}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DownloadResponse) GetFileContent() []byte {
@@ -219,7 +349,7 @@ type MerkleProofRequest struct {
 func (x *MerkleProofRequest) Reset() {
 	*x = MerkleProofRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[4]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -232,7 +362,7 @@ func (x *MerkleProofRequest) String() string {
 func (*MerkleProofRequest) ProtoMessage() {}
 
 func (x *MerkleProofRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[4]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,7 +375,7 @@ func (x *MerkleProofRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MerkleProofRequest.ProtoReflect.Descriptor instead.
 func (*MerkleProofRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{4}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *MerkleProofRequest) GetFileIndex() int64 {
@@ -270,7 +400,7 @@ type TreeNode struct {
 func (x *TreeNode) Reset() {
 	*x = TreeNode{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[5]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -283,7 +413,7 @@ func (x *TreeNode) String() string {
 func (*TreeNode) ProtoMessage() {}
 
 func (x *TreeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[5]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +426,7 @@ func (x *TreeNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TreeNode.ProtoReflect.Descriptor instead.
 func (*TreeNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TreeNode) GetHash() string {
@@ -340,12 +470,13 @@ type MerkleProofResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	Proofs []*TreeNode `protobuf:"bytes,1,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	Scheme *Scheme     `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
 }
 
 func (x *MerkleProofResponse) Reset() {
 	*x = MerkleProofResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[6]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -358,7 +489,7 @@ func (x *MerkleProofResponse) String() string {
 func (*MerkleProofResponse) ProtoMessage() {}
 
 func (x *MerkleProofResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[6]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +502,7 @@ func (x *MerkleProofResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MerkleProofResponse.ProtoReflect.Descriptor instead.
 func (*MerkleProofResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *MerkleProofResponse) GetProofs() []*TreeNode {
@@ -381,6 +512,13 @@ func (x *MerkleProofResponse) GetProofs() []*TreeNode {
 	return nil
 }
 
+func (x *MerkleProofResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
 type VerifyProofRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -390,12 +528,13 @@ type VerifyProofRequest struct {
 	FileHash  []byte      `protobuf:"bytes,2,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
 	FileIndex int64       `protobuf:"varint,3,opt,name=file_index,json=fileIndex,proto3" json:"file_index,omitempty"`
 	Proofs    []*TreeNode `protobuf:"bytes,4,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	Scheme    *Scheme     `protobuf:"bytes,5,opt,name=scheme,proto3" json:"scheme,omitempty"`
 }
 
 func (x *VerifyProofRequest) Reset() {
 	*x = VerifyProofRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[7]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -408,7 +547,7 @@ func (x *VerifyProofRequest) String() string {
 func (*VerifyProofRequest) ProtoMessage() {}
 
 func (x *VerifyProofRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[7]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +560,7 @@ func (x *VerifyProofRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyProofRequest.ProtoReflect.Descriptor instead.
 func (*VerifyProofRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VerifyProofRequest) GetRootHash() []byte {
@@ -452,6 +591,13 @@ func (x *VerifyProofRequest) GetProofs() []*TreeNode {
 	return nil
 }
 
+func (x *VerifyProofRequest) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
 type VerifyProofResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -463,7 +609,7 @@ type VerifyProofResponse struct {
 func (x *VerifyProofResponse) Reset() {
 	*x = VerifyProofResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_proto_merkle_proto_msgTypes[8]
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -476,7 +622,7 @@ func (x *VerifyProofResponse) String() string {
 func (*VerifyProofResponse) ProtoMessage() {}
 
 func (x *VerifyProofResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_proto_merkle_proto_msgTypes[8]
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +635,7 @@ func (x *VerifyProofResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyProofResponse.ProtoReflect.Descriptor instead.
 func (*VerifyProofResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{8}
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *VerifyProofResponse) GetIsVerified() bool {
@@ -499,182 +645,2332 @@ func (x *VerifyProofResponse) GetIsVerified() bool {
 	return false
 }
 
-var File_api_v1_proto_merkle_proto protoreflect.FileDescriptor
+type AppendFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_api_v1_proto_merkle_proto_rawDesc = []byte{
-	0x0a, 0x19, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d,
-	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6d, 0x65, 0x72,
-	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x22, 0x25, 0x0a, 0x0d, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69,
-	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
-	0x22, 0x3a, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f,
-	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6d, 0x65,
-	0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0x30, 0x0a, 0x0f,
-	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x35,
-	0x0a, 0x10, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x33, 0x0a, 0x12, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
-	0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xb0, 0x01, 0x0a, 0x08, 0x54,
-	0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x6c,
-	0x65, 0x66, 0x74, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c,
-	0x65, 0x66, 0x74, 0x49, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f,
-	0x69, 0x64, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x69, 0x67, 0x68, 0x74,
-	0x49, 0x64, 0x78, 0x12, 0x2a, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64,
-	0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12,
-	0x2c, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72,
-	0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x22, 0x45, 0x0a,
-	0x13, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61,
-	0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72,
-	0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x72, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e,
-	0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61,
-	0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x73, 0x22, 0x36, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
-	0x73, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0a, 0x69, 0x73, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x32, 0xcd, 0x02, 0x0a,
-	0x0a, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1b, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67,
-	0x61, 0x75, 0x72, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
-	0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x49, 0x0a, 0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1d, 0x2e, 0x6d,
-	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e,
-	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x65,
-	0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x47,
-	0x65, 0x74, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x20, 0x2e,
-	0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d, 0x65, 0x72,
-	0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d,
-	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x65, 0x72, 0x6b,
-	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
-	0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f,
-	0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x72, 0x6b,
-	0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6e, 0x61,
-	0x74, 0x68, 0x6c, 0x6e, 0x37, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
-	0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Files  [][]byte `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Scheme *Scheme  `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// File names of the appended files, required if the files were uploaded with file names
+	FileNames []string `protobuf:"bytes,3,rep,name=file_names,json=fileNames,proto3" json:"file_names,omitempty"`
+	// Merkle root hash and number of files the client recorded, which the server's tree has to match
+	OldMerkleRootHash []byte `protobuf:"bytes,4,opt,name=old_merkle_root_hash,json=oldMerkleRootHash,proto3" json:"old_merkle_root_hash,omitempty"`
+	OldLeafCount      int64  `protobuf:"varint,5,opt,name=old_leaf_count,json=oldLeafCount,proto3" json:"old_leaf_count,omitempty"`
 }
 
-var (
-	file_api_v1_proto_merkle_proto_rawDescOnce sync.Once
-	file_api_v1_proto_merkle_proto_rawDescData = file_api_v1_proto_merkle_proto_rawDesc
-)
+func (x *AppendFilesRequest) Reset() {
+	*x = AppendFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_api_v1_proto_merkle_proto_rawDescGZIP() []byte {
-	file_api_v1_proto_merkle_proto_rawDescOnce.Do(func() {
-		file_api_v1_proto_merkle_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v1_proto_merkle_proto_rawDescData)
-	})
-	return file_api_v1_proto_merkle_proto_rawDescData
+func (x *AppendFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_api_v1_proto_merkle_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_api_v1_proto_merkle_proto_goTypes = []interface{}{
-	(*UploadRequest)(nil),       // 0: merkle_gaurd.UploadRequest
-	(*UploadResponse)(nil),      // 1: merkle_gaurd.UploadResponse
-	(*DownloadRequest)(nil),     // 2: merkle_gaurd.DownloadRequest
-	(*DownloadResponse)(nil),    // 3: merkle_gaurd.DownloadResponse
-	(*MerkleProofRequest)(nil),  // 4: merkle_gaurd.MerkleProofRequest
-	(*TreeNode)(nil),            // 5: merkle_gaurd.TreeNode
-	(*MerkleProofResponse)(nil), // 6: merkle_gaurd.MerkleProofResponse
-	(*VerifyProofRequest)(nil),  // 7: merkle_gaurd.VerifyProofRequest
-	(*VerifyProofResponse)(nil), // 8: merkle_gaurd.VerifyProofResponse
+func (*AppendFilesRequest) ProtoMessage() {}
+
+func (x *AppendFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_api_v1_proto_merkle_proto_depIdxs = []int32{
-	5, // 0: merkle_gaurd.TreeNode.left:type_name -> merkle_gaurd.TreeNode
-	5, // 1: merkle_gaurd.TreeNode.right:type_name -> merkle_gaurd.TreeNode
-	5, // 2: merkle_gaurd.MerkleProofResponse.proofs:type_name -> merkle_gaurd.TreeNode
-	5, // 3: merkle_gaurd.VerifyProofRequest.proofs:type_name -> merkle_gaurd.TreeNode
-	0, // 4: merkle_gaurd.MerkleTree.Upload:input_type -> merkle_gaurd.UploadRequest
-	2, // 5: merkle_gaurd.MerkleTree.Download:input_type -> merkle_gaurd.DownloadRequest
-	4, // 6: merkle_gaurd.MerkleTree.GetMerkleProof:input_type -> merkle_gaurd.MerkleProofRequest
-	7, // 7: merkle_gaurd.MerkleTree.VerifyMerkleProof:input_type -> merkle_gaurd.VerifyProofRequest
-	1, // 8: merkle_gaurd.MerkleTree.Upload:output_type -> merkle_gaurd.UploadResponse
-	3, // 9: merkle_gaurd.MerkleTree.Download:output_type -> merkle_gaurd.DownloadResponse
-	6, // 10: merkle_gaurd.MerkleTree.GetMerkleProof:output_type -> merkle_gaurd.MerkleProofResponse
-	8, // 11: merkle_gaurd.MerkleTree.VerifyMerkleProof:output_type -> merkle_gaurd.VerifyProofResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+// Deprecated: Use AppendFilesRequest.ProtoReflect.Descriptor instead.
+func (*AppendFilesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{10}
 }
 
-func init() { file_api_v1_proto_merkle_proto_init() }
-func file_api_v1_proto_merkle_proto_init() {
-	if File_api_v1_proto_merkle_proto != nil {
-		return
+func (x *AppendFilesRequest) GetFiles() [][]byte {
+	if x != nil {
+		return x.Files
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_v1_proto_merkle_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_v1_proto_merkle_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_v1_proto_merkle_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownloadRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_v1_proto_merkle_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DownloadResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+	return nil
+}
+
+func (x *AppendFilesRequest) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *AppendFilesRequest) GetFileNames() []string {
+	if x != nil {
+		return x.FileNames
+	}
+	return nil
+}
+
+func (x *AppendFilesRequest) GetOldMerkleRootHash() []byte {
+	if x != nil {
+		return x.OldMerkleRootHash
+	}
+	return nil
+}
+
+func (x *AppendFilesRequest) GetOldLeafCount() int64 {
+	if x != nil {
+		return x.OldLeafCount
+	}
+	return 0
+}
+
+type AppendFilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MerkleRootHash []byte  `protobuf:"bytes,1,opt,name=merkle_root_hash,json=merkleRootHash,proto3" json:"merkle_root_hash,omitempty"`
+	LeafCount      int64   `protobuf:"varint,2,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	Scheme         *Scheme `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	SparseRootHash []byte  `protobuf:"bytes,4,opt,name=sparse_root_hash,json=sparseRootHash,proto3" json:"sparse_root_hash,omitempty"`
+	// Hexadecimal digests of the peaks of the merkle tree before the append from left to right, from
+	// which the client recomputes the old and the new merkle root hash
+	OldPeaks [][]byte `protobuf:"bytes,5,rep,name=old_peaks,json=oldPeaks,proto3" json:"old_peaks,omitempty"`
+	// Exclusion proofs of the file names, each against the sparse root hash before the file was inserted
+	SparseProofs []*SparseProof `protobuf:"bytes,6,rep,name=sparse_proofs,json=sparseProofs,proto3" json:"sparse_proofs,omitempty"`
+}
+
+func (x *AppendFilesResponse) Reset() {
+	*x = AppendFilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendFilesResponse) ProtoMessage() {}
+
+func (x *AppendFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendFilesResponse.ProtoReflect.Descriptor instead.
+func (*AppendFilesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AppendFilesResponse) GetMerkleRootHash() []byte {
+	if x != nil {
+		return x.MerkleRootHash
+	}
+	return nil
+}
+
+func (x *AppendFilesResponse) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *AppendFilesResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *AppendFilesResponse) GetSparseRootHash() []byte {
+	if x != nil {
+		return x.SparseRootHash
+	}
+	return nil
+}
+
+func (x *AppendFilesResponse) GetOldPeaks() [][]byte {
+	if x != nil {
+		return x.OldPeaks
+	}
+	return nil
+}
+
+func (x *AppendFilesResponse) GetSparseProofs() []*SparseProof {
+	if x != nil {
+		return x.SparseProofs
+	}
+	return nil
+}
+
+type ReplaceFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileIndex   int64   `protobuf:"varint,1,opt,name=file_index,json=fileIndex,proto3" json:"file_index,omitempty"`
+	FileContent []byte  `protobuf:"bytes,2,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	Scheme      *Scheme `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Name of the file stored at the index, required if the files are keyed by name
+	FileName string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+}
+
+func (x *ReplaceFileRequest) Reset() {
+	*x = ReplaceFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceFileRequest) ProtoMessage() {}
+
+func (x *ReplaceFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceFileRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReplaceFileRequest) GetFileIndex() int64 {
+	if x != nil {
+		return x.FileIndex
+	}
+	return 0
+}
+
+func (x *ReplaceFileRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *ReplaceFileRequest) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *ReplaceFileRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type ReplaceFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OldMerkleRootHash []byte `protobuf:"bytes,1,opt,name=old_merkle_root_hash,json=oldMerkleRootHash,proto3" json:"old_merkle_root_hash,omitempty"`
+	NewMerkleRootHash []byte `protobuf:"bytes,2,opt,name=new_merkle_root_hash,json=newMerkleRootHash,proto3" json:"new_merkle_root_hash,omitempty"`
+	// Hexadecimal leaf hash of the replaced file
+	OldFileHash       []byte      `protobuf:"bytes,3,opt,name=old_file_hash,json=oldFileHash,proto3" json:"old_file_hash,omitempty"`
+	Proofs            []*TreeNode `protobuf:"bytes,4,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	LeafCount         int64       `protobuf:"varint,5,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	Scheme            *Scheme     `protobuf:"bytes,6,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	NewSparseRootHash []byte      `protobuf:"bytes,7,opt,name=new_sparse_root_hash,json=newSparseRootHash,proto3" json:"new_sparse_root_hash,omitempty"`
+	// Name of the replaced file and its inclusion proof against the sparse root hash before the replacement
+	FileName    string       `protobuf:"bytes,8,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	SparseProof *SparseProof `protobuf:"bytes,9,opt,name=sparse_proof,json=sparseProof,proto3" json:"sparse_proof,omitempty"`
+}
+
+func (x *ReplaceFileResponse) Reset() {
+	*x = ReplaceFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceFileResponse) ProtoMessage() {}
+
+func (x *ReplaceFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceFileResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceFileResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReplaceFileResponse) GetOldMerkleRootHash() []byte {
+	if x != nil {
+		return x.OldMerkleRootHash
+	}
+	return nil
+}
+
+func (x *ReplaceFileResponse) GetNewMerkleRootHash() []byte {
+	if x != nil {
+		return x.NewMerkleRootHash
+	}
+	return nil
+}
+
+func (x *ReplaceFileResponse) GetOldFileHash() []byte {
+	if x != nil {
+		return x.OldFileHash
+	}
+	return nil
+}
+
+func (x *ReplaceFileResponse) GetProofs() []*TreeNode {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+func (x *ReplaceFileResponse) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *ReplaceFileResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *ReplaceFileResponse) GetNewSparseRootHash() []byte {
+	if x != nil {
+		return x.NewSparseRootHash
+	}
+	return nil
+}
+
+func (x *ReplaceFileResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ReplaceFileResponse) GetSparseProof() *SparseProof {
+	if x != nil {
+		return x.SparseProof
+	}
+	return nil
+}
+
+type MultiProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileIndices []int64 `protobuf:"varint,1,rep,packed,name=file_indices,json=fileIndices,proto3" json:"file_indices,omitempty"`
+}
+
+func (x *MultiProofRequest) Reset() {
+	*x = MultiProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiProofRequest) ProtoMessage() {}
+
+func (x *MultiProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiProofRequest.ProtoReflect.Descriptor instead.
+func (*MultiProofRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MultiProofRequest) GetFileIndices() []int64 {
+	if x != nil {
+		return x.FileIndices
+	}
+	return nil
+}
+
+type MultiProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proofs []*TreeNode `protobuf:"bytes,1,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	Scheme *Scheme     `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *MultiProofResponse) Reset() {
+	*x = MultiProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiProofResponse) ProtoMessage() {}
+
+func (x *MultiProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiProofResponse.ProtoReflect.Descriptor instead.
+func (*MultiProofResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MultiProofResponse) GetProofs() []*TreeNode {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+func (x *MultiProofResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+type DownloadRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromIndex int64 `protobuf:"varint,1,opt,name=from_index,json=fromIndex,proto3" json:"from_index,omitempty"`
+	ToIndex   int64 `protobuf:"varint,2,opt,name=to_index,json=toIndex,proto3" json:"to_index,omitempty"`
+}
+
+func (x *DownloadRangeRequest) Reset() {
+	*x = DownloadRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadRangeRequest) ProtoMessage() {}
+
+func (x *DownloadRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadRangeRequest.ProtoReflect.Descriptor instead.
+func (*DownloadRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DownloadRangeRequest) GetFromIndex() int64 {
+	if x != nil {
+		return x.FromIndex
+	}
+	return 0
+}
+
+func (x *DownloadRangeRequest) GetToIndex() int64 {
+	if x != nil {
+		return x.ToIndex
+	}
+	return 0
+}
+
+type RangeProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proofs    []*TreeNode `protobuf:"bytes,1,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	LeafCount int64       `protobuf:"varint,2,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	Scheme    *Scheme     `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *RangeProof) Reset() {
+	*x = RangeProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeProof) ProtoMessage() {}
+
+func (x *RangeProof) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeProof.ProtoReflect.Descriptor instead.
+func (*RangeProof) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RangeProof) GetProofs() []*TreeNode {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+func (x *RangeProof) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *RangeProof) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+// The files of the range are streamed in order, followed by the range proof as the last message
+type DownloadRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*DownloadRangeResponse_FileContent
+	//	*DownloadRangeResponse_RangeProof
+	Payload isDownloadRangeResponse_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *DownloadRangeResponse) Reset() {
+	*x = DownloadRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadRangeResponse) ProtoMessage() {}
+
+func (x *DownloadRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadRangeResponse.ProtoReflect.Descriptor instead.
+func (*DownloadRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{18}
+}
+
+func (m *DownloadRangeResponse) GetPayload() isDownloadRangeResponse_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *DownloadRangeResponse) GetFileContent() []byte {
+	if x, ok := x.GetPayload().(*DownloadRangeResponse_FileContent); ok {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *DownloadRangeResponse) GetRangeProof() *RangeProof {
+	if x, ok := x.GetPayload().(*DownloadRangeResponse_RangeProof); ok {
+		return x.RangeProof
+	}
+	return nil
+}
+
+type isDownloadRangeResponse_Payload interface {
+	isDownloadRangeResponse_Payload()
+}
+
+type DownloadRangeResponse_FileContent struct {
+	FileContent []byte `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3,oneof"`
+}
+
+type DownloadRangeResponse_RangeProof struct {
+	RangeProof *RangeProof `protobuf:"bytes,2,opt,name=range_proof,json=rangeProof,proto3,oneof"`
+}
+
+func (*DownloadRangeResponse_FileContent) isDownloadRangeResponse_Payload() {}
+
+func (*DownloadRangeResponse_RangeProof) isDownloadRangeResponse_Payload() {}
+
+type ConsistencyProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OldLeafCount int64 `protobuf:"varint,1,opt,name=old_leaf_count,json=oldLeafCount,proto3" json:"old_leaf_count,omitempty"`
+}
+
+func (x *ConsistencyProofRequest) Reset() {
+	*x = ConsistencyProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsistencyProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyProofRequest) ProtoMessage() {}
+
+func (x *ConsistencyProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyProofRequest.ProtoReflect.Descriptor instead.
+func (*ConsistencyProofRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConsistencyProofRequest) GetOldLeafCount() int64 {
+	if x != nil {
+		return x.OldLeafCount
+	}
+	return 0
+}
+
+type ConsistencyProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proofs         []*TreeNode `protobuf:"bytes,1,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	MerkleRootHash []byte      `protobuf:"bytes,2,opt,name=merkle_root_hash,json=merkleRootHash,proto3" json:"merkle_root_hash,omitempty"`
+	LeafCount      int64       `protobuf:"varint,3,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	Scheme         *Scheme     `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *ConsistencyProofResponse) Reset() {
+	*x = ConsistencyProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsistencyProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyProofResponse) ProtoMessage() {}
+
+func (x *ConsistencyProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyProofResponse.ProtoReflect.Descriptor instead.
+func (*ConsistencyProofResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConsistencyProofResponse) GetProofs() []*TreeNode {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+func (x *ConsistencyProofResponse) GetMerkleRootHash() []byte {
+	if x != nil {
+		return x.MerkleRootHash
+	}
+	return nil
+}
+
+func (x *ConsistencyProofResponse) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *ConsistencyProofResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+type DownloadChunkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileIndex  int64 `protobuf:"varint,1,opt,name=file_index,json=fileIndex,proto3" json:"file_index,omitempty"`
+	ChunkIndex int64 `protobuf:"varint,2,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+}
+
+func (x *DownloadChunkRequest) Reset() {
+	*x = DownloadChunkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadChunkRequest) ProtoMessage() {}
+
+func (x *DownloadChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadChunkRequest.ProtoReflect.Descriptor instead.
+func (*DownloadChunkRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DownloadChunkRequest) GetFileIndex() int64 {
+	if x != nil {
+		return x.FileIndex
+	}
+	return 0
+}
+
+func (x *DownloadChunkRequest) GetChunkIndex() int64 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+type DownloadChunkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChunkContent []byte `protobuf:"bytes,1,opt,name=chunk_content,json=chunkContent,proto3" json:"chunk_content,omitempty"`
+	ChunkCount   int64  `protobuf:"varint,2,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	// Proof path of the chunk in the sub-tree of the file
+	ChunkProofs []*TreeNode `protobuf:"bytes,3,rep,name=chunk_proofs,json=chunkProofs,proto3" json:"chunk_proofs,omitempty"`
+	// Proof path of the file in the merkle tree
+	FileProofs []*TreeNode `protobuf:"bytes,4,rep,name=file_proofs,json=fileProofs,proto3" json:"file_proofs,omitempty"`
+	LeafCount  int64       `protobuf:"varint,5,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	Scheme     *Scheme     `protobuf:"bytes,6,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *DownloadChunkResponse) Reset() {
+	*x = DownloadChunkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadChunkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadChunkResponse) ProtoMessage() {}
+
+func (x *DownloadChunkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadChunkResponse.ProtoReflect.Descriptor instead.
+func (*DownloadChunkResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DownloadChunkResponse) GetChunkContent() []byte {
+	if x != nil {
+		return x.ChunkContent
+	}
+	return nil
+}
+
+func (x *DownloadChunkResponse) GetChunkCount() int64 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *DownloadChunkResponse) GetChunkProofs() []*TreeNode {
+	if x != nil {
+		return x.ChunkProofs
+	}
+	return nil
+}
+
+func (x *DownloadChunkResponse) GetFileProofs() []*TreeNode {
+	if x != nil {
+		return x.FileProofs
+	}
+	return nil
+}
+
+func (x *DownloadChunkResponse) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *DownloadChunkResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+// FileManifest lists the content addresses of the chunks a file consists of in their order
+type FileManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChunkKeys []string `protobuf:"bytes,1,rep,name=chunk_keys,json=chunkKeys,proto3" json:"chunk_keys,omitempty"`
+}
+
+func (x *FileManifest) Reset() {
+	*x = FileManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileManifest) ProtoMessage() {}
+
+func (x *FileManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileManifest.ProtoReflect.Descriptor instead.
+func (*FileManifest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FileManifest) GetChunkKeys() []string {
+	if x != nil {
+		return x.ChunkKeys
+	}
+	return nil
+}
+
+type MissingChunksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChunkKeys []string `protobuf:"bytes,1,rep,name=chunk_keys,json=chunkKeys,proto3" json:"chunk_keys,omitempty"`
+}
+
+func (x *MissingChunksRequest) Reset() {
+	*x = MissingChunksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MissingChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissingChunksRequest) ProtoMessage() {}
+
+func (x *MissingChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissingChunksRequest.ProtoReflect.Descriptor instead.
+func (*MissingChunksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MissingChunksRequest) GetChunkKeys() []string {
+	if x != nil {
+		return x.ChunkKeys
+	}
+	return nil
+}
+
+type MissingChunksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys of the requested chunks the server does not store yet
+	ChunkKeys []string `protobuf:"bytes,1,rep,name=chunk_keys,json=chunkKeys,proto3" json:"chunk_keys,omitempty"`
+}
+
+func (x *MissingChunksResponse) Reset() {
+	*x = MissingChunksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MissingChunksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissingChunksResponse) ProtoMessage() {}
+
+func (x *MissingChunksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissingChunksResponse.ProtoReflect.Descriptor instead.
+func (*MissingChunksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MissingChunksResponse) GetChunkKeys() []string {
+	if x != nil {
+		return x.ChunkKeys
+	}
+	return nil
+}
+
+// UploadChunksRequest uploads files as manifests of chunks, of which only the ones missing on the server are sent along
+type UploadChunksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manifests []*FileManifest `protobuf:"bytes,1,rep,name=manifests,proto3" json:"manifests,omitempty"`
+	Chunks    [][]byte        `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	FileNames []string        `protobuf:"bytes,3,rep,name=file_names,json=fileNames,proto3" json:"file_names,omitempty"`
+	Scheme    *Scheme         `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *UploadChunksRequest) Reset() {
+	*x = UploadChunksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunksRequest) ProtoMessage() {}
+
+func (x *UploadChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunksRequest.ProtoReflect.Descriptor instead.
+func (*UploadChunksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UploadChunksRequest) GetManifests() []*FileManifest {
+	if x != nil {
+		return x.Manifests
+	}
+	return nil
+}
+
+func (x *UploadChunksRequest) GetChunks() [][]byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *UploadChunksRequest) GetFileNames() []string {
+	if x != nil {
+		return x.FileNames
+	}
+	return nil
+}
+
+func (x *UploadChunksRequest) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+// UploadStreamRequest carries the next piece of a file streamed with UploadStream. Files are sent one after
+// another, each split into as many pieces as needed and completed by a piece with end_of_file set
+type UploadStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only read from the first piece of the stream
+	Scheme *Scheme `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Only read from the first piece of every file. Either all files or none have names
+	FileName  string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Content   []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	EndOfFile bool   `protobuf:"varint,4,opt,name=end_of_file,json=endOfFile,proto3" json:"end_of_file,omitempty"`
+}
+
+func (x *UploadStreamRequest) Reset() {
+	*x = UploadStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStreamRequest) ProtoMessage() {}
+
+func (x *UploadStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStreamRequest.ProtoReflect.Descriptor instead.
+func (*UploadStreamRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UploadStreamRequest) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+func (x *UploadStreamRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadStreamRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *UploadStreamRequest) GetEndOfFile() bool {
+	if x != nil {
+		return x.EndOfFile
+	}
+	return false
+}
+
+type KeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+}
+
+func (x *KeyRequest) Reset() {
+	*x = KeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRequest) ProtoMessage() {}
+
+func (x *KeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRequest.ProtoReflect.Descriptor instead.
+func (*KeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *KeyRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+// SparseProof proves the presence or the absence of a file name in the sparse merkle tree
+type SparseProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Hexadecimal sibling hashes ordered from the leaf up to the root
+	Siblings []string `protobuf:"bytes,1,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	// Hexadecimal key and value hash of the leaf the path ends at, empty if it ends at an empty subtree
+	LeafKey       string `protobuf:"bytes,2,opt,name=leaf_key,json=leafKey,proto3" json:"leaf_key,omitempty"`
+	LeafValueHash string `protobuf:"bytes,3,opt,name=leaf_value_hash,json=leafValueHash,proto3" json:"leaf_value_hash,omitempty"`
+}
+
+func (x *SparseProof) Reset() {
+	*x = SparseProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SparseProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SparseProof) ProtoMessage() {}
+
+func (x *SparseProof) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SparseProof.ProtoReflect.Descriptor instead.
+func (*SparseProof) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SparseProof) GetSiblings() []string {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+func (x *SparseProof) GetLeafKey() string {
+	if x != nil {
+		return x.LeafKey
+	}
+	return ""
+}
+
+func (x *SparseProof) GetLeafValueHash() string {
+	if x != nil {
+		return x.LeafValueHash
+	}
+	return ""
+}
+
+type GetByKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileContent []byte       `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	FileIndex   int64        `protobuf:"varint,2,opt,name=file_index,json=fileIndex,proto3" json:"file_index,omitempty"`
+	Proof       *SparseProof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	Scheme      *Scheme      `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *GetByKeyResponse) Reset() {
+	*x = GetByKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetByKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByKeyResponse) ProtoMessage() {}
+
+func (x *GetByKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetByKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetByKeyResponse) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *GetByKeyResponse) GetFileIndex() int64 {
+	if x != nil {
+		return x.FileIndex
+	}
+	return 0
+}
+
+func (x *GetByKeyResponse) GetProof() *SparseProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *GetByKeyResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+type ProveAbsentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof  *SparseProof `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	Scheme *Scheme      `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *ProveAbsentResponse) Reset() {
+	*x = ProveAbsentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProveAbsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProveAbsentResponse) ProtoMessage() {}
+
+func (x *ProveAbsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProveAbsentResponse.ProtoReflect.Descriptor instead.
+func (*ProveAbsentResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ProveAbsentResponse) GetProof() *SparseProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ProveAbsentResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+// NodesRequest requests the nodes on the positions from_index to to_index of a level of the merkle tree,
+// the root being on level 0 and the nodes of a level being numbered from left to right
+type NodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level     int64 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	FromIndex int64 `protobuf:"varint,2,opt,name=from_index,json=fromIndex,proto3" json:"from_index,omitempty"`
+	ToIndex   int64 `protobuf:"varint,3,opt,name=to_index,json=toIndex,proto3" json:"to_index,omitempty"`
+}
+
+func (x *NodesRequest) Reset() {
+	*x = NodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodesRequest) ProtoMessage() {}
+
+func (x *NodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodesRequest.ProtoReflect.Descriptor instead.
+func (*NodesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *NodesRequest) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *NodesRequest) GetFromIndex() int64 {
+	if x != nil {
+		return x.FromIndex
+	}
+	return 0
+}
+
+func (x *NodesRequest) GetToIndex() int64 {
+	if x != nil {
+		return x.ToIndex
+	}
+	return 0
+}
+
+type NodesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Nodes without their children
+	Nodes     []*TreeNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	LeafCount int64       `protobuf:"varint,2,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	Scheme    *Scheme     `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *NodesResponse) Reset() {
+	*x = NodesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodesResponse) ProtoMessage() {}
+
+func (x *NodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodesResponse.ProtoReflect.Descriptor instead.
+func (*NodesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *NodesResponse) GetNodes() []*TreeNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *NodesResponse) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *NodesResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+type TreeInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TreeInfoRequest) Reset() {
+	*x = TreeInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeInfoRequest) ProtoMessage() {}
+
+func (x *TreeInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeInfoRequest.ProtoReflect.Descriptor instead.
+func (*TreeInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{34}
+}
+
+// TreeInfoResponse carries the statistics of the merkle tree
+type TreeInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MerkleRootHash []byte `protobuf:"bytes,1,opt,name=merkle_root_hash,json=merkleRootHash,proto3" json:"merkle_root_hash,omitempty"`
+	LeafCount      int64  `protobuf:"varint,2,opt,name=leaf_count,json=leafCount,proto3" json:"leaf_count,omitempty"`
+	NodeCount      int64  `protobuf:"varint,3,opt,name=node_count,json=nodeCount,proto3" json:"node_count,omitempty"`
+	// Number of levels from the root down to the deepest leaf
+	Height int64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// Size of the digests of all nodes
+	TotalBytes    int64  `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	HashAlgorithm string `protobuf:"bytes,6,opt,name=hash_algorithm,json=hashAlgorithm,proto3" json:"hash_algorithm,omitempty"`
+	// Time the server took to build the merkle tree on upload
+	BuildDurationNs int64   `protobuf:"varint,7,opt,name=build_duration_ns,json=buildDurationNs,proto3" json:"build_duration_ns,omitempty"`
+	Scheme          *Scheme `protobuf:"bytes,8,opt,name=scheme,proto3" json:"scheme,omitempty"`
+}
+
+func (x *TreeInfoResponse) Reset() {
+	*x = TreeInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_proto_merkle_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeInfoResponse) ProtoMessage() {}
+
+func (x *TreeInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_proto_merkle_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeInfoResponse.ProtoReflect.Descriptor instead.
+func (*TreeInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_proto_merkle_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TreeInfoResponse) GetMerkleRootHash() []byte {
+	if x != nil {
+		return x.MerkleRootHash
+	}
+	return nil
+}
+
+func (x *TreeInfoResponse) GetLeafCount() int64 {
+	if x != nil {
+		return x.LeafCount
+	}
+	return 0
+}
+
+func (x *TreeInfoResponse) GetNodeCount() int64 {
+	if x != nil {
+		return x.NodeCount
+	}
+	return 0
+}
+
+func (x *TreeInfoResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *TreeInfoResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *TreeInfoResponse) GetHashAlgorithm() string {
+	if x != nil {
+		return x.HashAlgorithm
+	}
+	return ""
+}
+
+func (x *TreeInfoResponse) GetBuildDurationNs() int64 {
+	if x != nil {
+		return x.BuildDurationNs
+	}
+	return 0
+}
+
+func (x *TreeInfoResponse) GetScheme() *Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return nil
+}
+
+var File_api_v1_proto_merkle_proto protoreflect.FileDescriptor
+
+var file_api_v1_proto_merkle_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x06, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x68, 0x61,
+	0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x2b, 0x0a, 0x11, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65,
+	0x70, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x73, 0x68, 0x61, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x69, 0x6e, 0x67, 0x22, 0x72, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65,
+	0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61,
+	0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x72, 0x6f, 0x6f,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0x30, 0x0a, 0x0f,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x35,
+	0x0a, 0x10, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x33, 0x0a, 0x12, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xb0, 0x01, 0x0a, 0x08, 0x54,
+	0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x6c,
+	0x65, 0x66, 0x74, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c,
+	0x65, 0x66, 0x74, 0x49, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f,
+	0x69, 0x64, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x49, 0x64, 0x78, 0x12, 0x2a, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64,
+	0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12,
+	0x2c, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72,
+	0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x22, 0x73, 0x0a,
+	0x13, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61,
+	0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61,
+	0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6f,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x6f,
+	0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x22, 0x36, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x22, 0xce, 0x01, 0x0a, 0x12, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67,
+	0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x6f, 0x6c, 0x64, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x11, 0x6f, 0x6c, 0x64, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x24, 0x0a, 0x0e, 0x6f, 0x6c, 0x64, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6f, 0x6c, 0x64,
+	0x4c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x93, 0x02, 0x0a, 0x13, 0x41, 0x70,
+	0x70, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x70, 0x61, 0x72,
+	0x73, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x61, 0x6b, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x61, 0x6b, 0x73, 0x12,
+	0x3e, 0x0a, 0x0d, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x0c, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x22,
+	0xa1, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x66, 0x69, 0x6c,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x22, 0xa4, 0x03, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x14, 0x6f,
+	0x6c, 0x64, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6f, 0x6c, 0x64, 0x4d, 0x65,
+	0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2f, 0x0a, 0x14,
+	0x6e, 0x65, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6e, 0x65, 0x77, 0x4d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x22, 0x0a,
+	0x0d, 0x6f, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64,
+	0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x2f,
+	0x0a, 0x14, 0x6e, 0x65, 0x77, 0x5f, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x72, 0x6f, 0x6f,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11, 0x6e, 0x65,
+	0x77, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x0c,
+	0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0b, 0x73,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x36, 0x0a, 0x11, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63,
+	0x65, 0x73, 0x22, 0x72, 0x0a, 0x12, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x22, 0x50, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a,
+	0x08, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x74, 0x6f, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x89, 0x01, 0x0a, 0x0a, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61,
+	0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3f, 0x0a, 0x17, 0x43,
+	0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6f, 0x6c, 0x64, 0x5f, 0x6c, 0x65,
+	0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6f, 0x6c, 0x64, 0x4c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xc1, 0x01, 0x0a,
+	0x18, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x22, 0x56, 0x0a, 0x14, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x9e, 0x02, 0x0a, 0x15, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72,
+	0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x12, 0x37, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6c, 0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65,
+	0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x22, 0x2d, 0x0a, 0x0c, 0x46, 0x69, 0x6c,
+	0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x35, 0x0a, 0x14, 0x4d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x4b, 0x65, 0x79, 0x73, 0x22,
+	0x36, 0x0a, 0x15, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x4b, 0x65, 0x79, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x38, 0x0a, 0x09, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x52, 0x09,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x22, 0x9a,
+	0x01, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0b, 0x65,
+	0x6e, 0x64, 0x5f, 0x6f, 0x66, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x29, 0x0a, 0x0a, 0x4b,
+	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x6c, 0x0a, 0x0b, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x62, 0x6c, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x69, 0x62, 0x6c, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x66, 0x4b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x0f,
+	0x6c, 0x65, 0x61, 0x66, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x48, 0x61, 0x73, 0x68, 0x22, 0xb3, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x79, 0x4b, 0x65,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2f, 0x0a, 0x05, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2c, 0x0a, 0x06,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x22, 0x74, 0x0a, 0x13, 0x50, 0x72,
+	0x6f, 0x76, 0x65, 0x41, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e,
+	0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x22, 0x5e, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x8a, 0x01, 0x0a, 0x0d, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64,
+	0x2e, 0x54, 0x72, 0x65, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x65, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x22, 0x11, 0x0a,
+	0x0f, 0x54, 0x72, 0x65, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0xb4, 0x02, 0x0a, 0x10, 0x54, 0x72, 0x65, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x68, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x2a, 0x0a,
+	0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x52,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x32, 0x8b, 0x0b, 0x0a, 0x0a, 0x4d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x1b, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x08, 0x44,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1d, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a,
+	0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61,
+	0x75, 0x72, 0x64, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x65, 0x6e,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x25, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x52, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x20, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e,
+	0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72,
+	0x64, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1f, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x0d, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0d, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x12, 0x22, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75,
+	0x72, 0x64, 0x2e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0c,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x21, 0x2e, 0x6d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a,
+	0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x21, 0x2e,
+	0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01,
+	0x12, 0x44, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x2e, 0x6d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4b, 0x65, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x41,
+	0x62, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67,
+	0x61, 0x75, 0x72, 0x64, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x50,
+	0x72, 0x6f, 0x76, 0x65, 0x41, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1a,
+	0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x72,
+	0x65, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f,
+	0x67, 0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67,
+	0x61, 0x75, 0x72, 0x64, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6e, 0x61, 0x74, 0x68, 0x6c, 0x6e, 0x37, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x67, 0x61, 0x75, 0x72, 0x64, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v1_proto_merkle_proto_rawDescOnce sync.Once
+	file_api_v1_proto_merkle_proto_rawDescData = file_api_v1_proto_merkle_proto_rawDesc
+)
+
+func file_api_v1_proto_merkle_proto_rawDescGZIP() []byte {
+	file_api_v1_proto_merkle_proto_rawDescOnce.Do(func() {
+		file_api_v1_proto_merkle_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v1_proto_merkle_proto_rawDescData)
+	})
+	return file_api_v1_proto_merkle_proto_rawDescData
+}
+
+var file_api_v1_proto_merkle_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_api_v1_proto_merkle_proto_goTypes = []interface{}{
+	(*Scheme)(nil),                   // 0: merkle_gaurd.Scheme
+	(*UploadRequest)(nil),            // 1: merkle_gaurd.UploadRequest
+	(*UploadResponse)(nil),           // 2: merkle_gaurd.UploadResponse
+	(*DownloadRequest)(nil),          // 3: merkle_gaurd.DownloadRequest
+	(*DownloadResponse)(nil),         // 4: merkle_gaurd.DownloadResponse
+	(*MerkleProofRequest)(nil),       // 5: merkle_gaurd.MerkleProofRequest
+	(*TreeNode)(nil),                 // 6: merkle_gaurd.TreeNode
+	(*MerkleProofResponse)(nil),      // 7: merkle_gaurd.MerkleProofResponse
+	(*VerifyProofRequest)(nil),       // 8: merkle_gaurd.VerifyProofRequest
+	(*VerifyProofResponse)(nil),      // 9: merkle_gaurd.VerifyProofResponse
+	(*AppendFilesRequest)(nil),       // 10: merkle_gaurd.AppendFilesRequest
+	(*AppendFilesResponse)(nil),      // 11: merkle_gaurd.AppendFilesResponse
+	(*ReplaceFileRequest)(nil),       // 12: merkle_gaurd.ReplaceFileRequest
+	(*ReplaceFileResponse)(nil),      // 13: merkle_gaurd.ReplaceFileResponse
+	(*MultiProofRequest)(nil),        // 14: merkle_gaurd.MultiProofRequest
+	(*MultiProofResponse)(nil),       // 15: merkle_gaurd.MultiProofResponse
+	(*DownloadRangeRequest)(nil),     // 16: merkle_gaurd.DownloadRangeRequest
+	(*RangeProof)(nil),               // 17: merkle_gaurd.RangeProof
+	(*DownloadRangeResponse)(nil),    // 18: merkle_gaurd.DownloadRangeResponse
+	(*ConsistencyProofRequest)(nil),  // 19: merkle_gaurd.ConsistencyProofRequest
+	(*ConsistencyProofResponse)(nil), // 20: merkle_gaurd.ConsistencyProofResponse
+	(*DownloadChunkRequest)(nil),     // 21: merkle_gaurd.DownloadChunkRequest
+	(*DownloadChunkResponse)(nil),    // 22: merkle_gaurd.DownloadChunkResponse
+	(*FileManifest)(nil),             // 23: merkle_gaurd.FileManifest
+	(*MissingChunksRequest)(nil),     // 24: merkle_gaurd.MissingChunksRequest
+	(*MissingChunksResponse)(nil),    // 25: merkle_gaurd.MissingChunksResponse
+	(*UploadChunksRequest)(nil),      // 26: merkle_gaurd.UploadChunksRequest
+	(*UploadStreamRequest)(nil),      // 27: merkle_gaurd.UploadStreamRequest
+	(*KeyRequest)(nil),               // 28: merkle_gaurd.KeyRequest
+	(*SparseProof)(nil),              // 29: merkle_gaurd.SparseProof
+	(*GetByKeyResponse)(nil),         // 30: merkle_gaurd.GetByKeyResponse
+	(*ProveAbsentResponse)(nil),      // 31: merkle_gaurd.ProveAbsentResponse
+	(*NodesRequest)(nil),             // 32: merkle_gaurd.NodesRequest
+	(*NodesResponse)(nil),            // 33: merkle_gaurd.NodesResponse
+	(*TreeInfoRequest)(nil),          // 34: merkle_gaurd.TreeInfoRequest
+	(*TreeInfoResponse)(nil),         // 35: merkle_gaurd.TreeInfoResponse
+}
+var file_api_v1_proto_merkle_proto_depIdxs = []int32{
+	0,  // 0: merkle_gaurd.UploadRequest.scheme:type_name -> merkle_gaurd.Scheme
+	0,  // 1: merkle_gaurd.UploadResponse.scheme:type_name -> merkle_gaurd.Scheme
+	6,  // 2: merkle_gaurd.TreeNode.left:type_name -> merkle_gaurd.TreeNode
+	6,  // 3: merkle_gaurd.TreeNode.right:type_name -> merkle_gaurd.TreeNode
+	6,  // 4: merkle_gaurd.MerkleProofResponse.proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 5: merkle_gaurd.MerkleProofResponse.scheme:type_name -> merkle_gaurd.Scheme
+	6,  // 6: merkle_gaurd.VerifyProofRequest.proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 7: merkle_gaurd.VerifyProofRequest.scheme:type_name -> merkle_gaurd.Scheme
+	0,  // 8: merkle_gaurd.AppendFilesRequest.scheme:type_name -> merkle_gaurd.Scheme
+	0,  // 9: merkle_gaurd.AppendFilesResponse.scheme:type_name -> merkle_gaurd.Scheme
+	29, // 10: merkle_gaurd.AppendFilesResponse.sparse_proofs:type_name -> merkle_gaurd.SparseProof
+	0,  // 11: merkle_gaurd.ReplaceFileRequest.scheme:type_name -> merkle_gaurd.Scheme
+	6,  // 12: merkle_gaurd.ReplaceFileResponse.proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 13: merkle_gaurd.ReplaceFileResponse.scheme:type_name -> merkle_gaurd.Scheme
+	29, // 14: merkle_gaurd.ReplaceFileResponse.sparse_proof:type_name -> merkle_gaurd.SparseProof
+	6,  // 15: merkle_gaurd.MultiProofResponse.proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 16: merkle_gaurd.MultiProofResponse.scheme:type_name -> merkle_gaurd.Scheme
+	6,  // 17: merkle_gaurd.RangeProof.proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 18: merkle_gaurd.RangeProof.scheme:type_name -> merkle_gaurd.Scheme
+	17, // 19: merkle_gaurd.DownloadRangeResponse.range_proof:type_name -> merkle_gaurd.RangeProof
+	6,  // 20: merkle_gaurd.ConsistencyProofResponse.proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 21: merkle_gaurd.ConsistencyProofResponse.scheme:type_name -> merkle_gaurd.Scheme
+	6,  // 22: merkle_gaurd.DownloadChunkResponse.chunk_proofs:type_name -> merkle_gaurd.TreeNode
+	6,  // 23: merkle_gaurd.DownloadChunkResponse.file_proofs:type_name -> merkle_gaurd.TreeNode
+	0,  // 24: merkle_gaurd.DownloadChunkResponse.scheme:type_name -> merkle_gaurd.Scheme
+	23, // 25: merkle_gaurd.UploadChunksRequest.manifests:type_name -> merkle_gaurd.FileManifest
+	0,  // 26: merkle_gaurd.UploadChunksRequest.scheme:type_name -> merkle_gaurd.Scheme
+	0,  // 27: merkle_gaurd.UploadStreamRequest.scheme:type_name -> merkle_gaurd.Scheme
+	29, // 28: merkle_gaurd.GetByKeyResponse.proof:type_name -> merkle_gaurd.SparseProof
+	0,  // 29: merkle_gaurd.GetByKeyResponse.scheme:type_name -> merkle_gaurd.Scheme
+	29, // 30: merkle_gaurd.ProveAbsentResponse.proof:type_name -> merkle_gaurd.SparseProof
+	0,  // 31: merkle_gaurd.ProveAbsentResponse.scheme:type_name -> merkle_gaurd.Scheme
+	6,  // 32: merkle_gaurd.NodesResponse.nodes:type_name -> merkle_gaurd.TreeNode
+	0,  // 33: merkle_gaurd.NodesResponse.scheme:type_name -> merkle_gaurd.Scheme
+	0,  // 34: merkle_gaurd.TreeInfoResponse.scheme:type_name -> merkle_gaurd.Scheme
+	1,  // 35: merkle_gaurd.MerkleTree.Upload:input_type -> merkle_gaurd.UploadRequest
+	3,  // 36: merkle_gaurd.MerkleTree.Download:input_type -> merkle_gaurd.DownloadRequest
+	5,  // 37: merkle_gaurd.MerkleTree.GetMerkleProof:input_type -> merkle_gaurd.MerkleProofRequest
+	8,  // 38: merkle_gaurd.MerkleTree.VerifyMerkleProof:input_type -> merkle_gaurd.VerifyProofRequest
+	10, // 39: merkle_gaurd.MerkleTree.AppendFiles:input_type -> merkle_gaurd.AppendFilesRequest
+	19, // 40: merkle_gaurd.MerkleTree.GetConsistencyProof:input_type -> merkle_gaurd.ConsistencyProofRequest
+	12, // 41: merkle_gaurd.MerkleTree.ReplaceFile:input_type -> merkle_gaurd.ReplaceFileRequest
+	14, // 42: merkle_gaurd.MerkleTree.GetMultiProof:input_type -> merkle_gaurd.MultiProofRequest
+	16, // 43: merkle_gaurd.MerkleTree.DownloadRange:input_type -> merkle_gaurd.DownloadRangeRequest
+	21, // 44: merkle_gaurd.MerkleTree.DownloadChunk:input_type -> merkle_gaurd.DownloadChunkRequest
+	24, // 45: merkle_gaurd.MerkleTree.GetMissingChunks:input_type -> merkle_gaurd.MissingChunksRequest
+	26, // 46: merkle_gaurd.MerkleTree.UploadChunks:input_type -> merkle_gaurd.UploadChunksRequest
+	27, // 47: merkle_gaurd.MerkleTree.UploadStream:input_type -> merkle_gaurd.UploadStreamRequest
+	28, // 48: merkle_gaurd.MerkleTree.GetByKey:input_type -> merkle_gaurd.KeyRequest
+	28, // 49: merkle_gaurd.MerkleTree.ProveAbsent:input_type -> merkle_gaurd.KeyRequest
+	32, // 50: merkle_gaurd.MerkleTree.GetNodes:input_type -> merkle_gaurd.NodesRequest
+	34, // 51: merkle_gaurd.MerkleTree.GetTreeInfo:input_type -> merkle_gaurd.TreeInfoRequest
+	2,  // 52: merkle_gaurd.MerkleTree.Upload:output_type -> merkle_gaurd.UploadResponse
+	4,  // 53: merkle_gaurd.MerkleTree.Download:output_type -> merkle_gaurd.DownloadResponse
+	7,  // 54: merkle_gaurd.MerkleTree.GetMerkleProof:output_type -> merkle_gaurd.MerkleProofResponse
+	9,  // 55: merkle_gaurd.MerkleTree.VerifyMerkleProof:output_type -> merkle_gaurd.VerifyProofResponse
+	11, // 56: merkle_gaurd.MerkleTree.AppendFiles:output_type -> merkle_gaurd.AppendFilesResponse
+	20, // 57: merkle_gaurd.MerkleTree.GetConsistencyProof:output_type -> merkle_gaurd.ConsistencyProofResponse
+	13, // 58: merkle_gaurd.MerkleTree.ReplaceFile:output_type -> merkle_gaurd.ReplaceFileResponse
+	15, // 59: merkle_gaurd.MerkleTree.GetMultiProof:output_type -> merkle_gaurd.MultiProofResponse
+	18, // 60: merkle_gaurd.MerkleTree.DownloadRange:output_type -> merkle_gaurd.DownloadRangeResponse
+	22, // 61: merkle_gaurd.MerkleTree.DownloadChunk:output_type -> merkle_gaurd.DownloadChunkResponse
+	25, // 62: merkle_gaurd.MerkleTree.GetMissingChunks:output_type -> merkle_gaurd.MissingChunksResponse
+	2,  // 63: merkle_gaurd.MerkleTree.UploadChunks:output_type -> merkle_gaurd.UploadResponse
+	2,  // 64: merkle_gaurd.MerkleTree.UploadStream:output_type -> merkle_gaurd.UploadResponse
+	30, // 65: merkle_gaurd.MerkleTree.GetByKey:output_type -> merkle_gaurd.GetByKeyResponse
+	31, // 66: merkle_gaurd.MerkleTree.ProveAbsent:output_type -> merkle_gaurd.ProveAbsentResponse
+	33, // 67: merkle_gaurd.MerkleTree.GetNodes:output_type -> merkle_gaurd.NodesResponse
+	35, // 68: merkle_gaurd.MerkleTree.GetTreeInfo:output_type -> merkle_gaurd.TreeInfoResponse
+	52, // [52:69] is the sub-list for method output_type
+	35, // [35:52] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_proto_merkle_proto_init() }
+func file_api_v1_proto_merkle_proto_init() {
+	if File_api_v1_proto_merkle_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_v1_proto_merkle_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Scheme); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 		file_api_v1_proto_merkle_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MerkleProofRequest); i {
 			case 0:
 				return &v.state
@@ -686,8 +2982,32 @@ func file_api_v1_proto_merkle_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_merkle_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TreeNode); i {
+		file_api_v1_proto_merkle_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MerkleProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyProofRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -698,8 +3018,8 @@ func file_api_v1_proto_merkle_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_merkle_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MerkleProofResponse); i {
+		file_api_v1_proto_merkle_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyProofResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -710,8 +3030,8 @@ func file_api_v1_proto_merkle_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_merkle_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyProofRequest); i {
+		file_api_v1_proto_merkle_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendFilesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -722,8 +3042,296 @@ func file_api_v1_proto_merkle_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_proto_merkle_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyProofResponse); i {
+		file_api_v1_proto_merkle_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendFilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RangeProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsistencyProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsistencyProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadChunkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadChunkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MissingChunksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MissingChunksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadChunksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SparseProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetByKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProveAbsentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_proto_merkle_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -735,13 +3343,17 @@ func file_api_v1_proto_merkle_proto_init() {
 			}
 		}
 	}
+	file_api_v1_proto_merkle_proto_msgTypes[18].OneofWrappers = []interface{}{
+		(*DownloadRangeResponse_FileContent)(nil),
+		(*DownloadRangeResponse_RangeProof)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_proto_merkle_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
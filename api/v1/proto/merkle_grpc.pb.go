@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.23.3
+// source: api/v1/proto/merkle.proto
 
 package merkle_gaurd
 
@@ -11,6 +15,7 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
 // MerkleTreeClient is the client API for MerkleTree service.
@@ -21,6 +26,19 @@ type MerkleTreeClient interface {
 	Download(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (*DownloadResponse, error)
 	GetMerkleProof(ctx context.Context, in *MerkleProofRequest, opts ...grpc.CallOption) (*MerkleProofResponse, error)
 	VerifyMerkleProof(ctx context.Context, in *VerifyProofRequest, opts ...grpc.CallOption) (*VerifyProofResponse, error)
+	AppendFiles(ctx context.Context, in *AppendFilesRequest, opts ...grpc.CallOption) (*AppendFilesResponse, error)
+	GetConsistencyProof(ctx context.Context, in *ConsistencyProofRequest, opts ...grpc.CallOption) (*ConsistencyProofResponse, error)
+	ReplaceFile(ctx context.Context, in *ReplaceFileRequest, opts ...grpc.CallOption) (*ReplaceFileResponse, error)
+	GetMultiProof(ctx context.Context, in *MultiProofRequest, opts ...grpc.CallOption) (*MultiProofResponse, error)
+	DownloadRange(ctx context.Context, in *DownloadRangeRequest, opts ...grpc.CallOption) (MerkleTree_DownloadRangeClient, error)
+	DownloadChunk(ctx context.Context, in *DownloadChunkRequest, opts ...grpc.CallOption) (*DownloadChunkResponse, error)
+	GetMissingChunks(ctx context.Context, in *MissingChunksRequest, opts ...grpc.CallOption) (*MissingChunksResponse, error)
+	UploadChunks(ctx context.Context, in *UploadChunksRequest, opts ...grpc.CallOption) (*UploadResponse, error)
+	UploadStream(ctx context.Context, opts ...grpc.CallOption) (MerkleTree_UploadStreamClient, error)
+	GetByKey(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*GetByKeyResponse, error)
+	ProveAbsent(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*ProveAbsentResponse, error)
+	GetNodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
+	GetTreeInfo(ctx context.Context, in *TreeInfoRequest, opts ...grpc.CallOption) (*TreeInfoResponse, error)
 }
 
 type merkleTreeClient struct {
@@ -67,6 +85,171 @@ func (c *merkleTreeClient) VerifyMerkleProof(ctx context.Context, in *VerifyProo
 	return out, nil
 }
 
+func (c *merkleTreeClient) AppendFiles(ctx context.Context, in *AppendFilesRequest, opts ...grpc.CallOption) (*AppendFilesResponse, error) {
+	out := new(AppendFilesResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/AppendFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) GetConsistencyProof(ctx context.Context, in *ConsistencyProofRequest, opts ...grpc.CallOption) (*ConsistencyProofResponse, error) {
+	out := new(ConsistencyProofResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/GetConsistencyProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) ReplaceFile(ctx context.Context, in *ReplaceFileRequest, opts ...grpc.CallOption) (*ReplaceFileResponse, error) {
+	out := new(ReplaceFileResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/ReplaceFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) GetMultiProof(ctx context.Context, in *MultiProofRequest, opts ...grpc.CallOption) (*MultiProofResponse, error) {
+	out := new(MultiProofResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/GetMultiProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) DownloadRange(ctx context.Context, in *DownloadRangeRequest, opts ...grpc.CallOption) (MerkleTree_DownloadRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MerkleTree_ServiceDesc.Streams[0], "/merkle_gaurd.MerkleTree/DownloadRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &merkleTreeDownloadRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MerkleTree_DownloadRangeClient interface {
+	Recv() (*DownloadRangeResponse, error)
+	grpc.ClientStream
+}
+
+type merkleTreeDownloadRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *merkleTreeDownloadRangeClient) Recv() (*DownloadRangeResponse, error) {
+	m := new(DownloadRangeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *merkleTreeClient) DownloadChunk(ctx context.Context, in *DownloadChunkRequest, opts ...grpc.CallOption) (*DownloadChunkResponse, error) {
+	out := new(DownloadChunkResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/DownloadChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) GetMissingChunks(ctx context.Context, in *MissingChunksRequest, opts ...grpc.CallOption) (*MissingChunksResponse, error) {
+	out := new(MissingChunksResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/GetMissingChunks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) UploadChunks(ctx context.Context, in *UploadChunksRequest, opts ...grpc.CallOption) (*UploadResponse, error) {
+	out := new(UploadResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/UploadChunks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) UploadStream(ctx context.Context, opts ...grpc.CallOption) (MerkleTree_UploadStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MerkleTree_ServiceDesc.Streams[1], "/merkle_gaurd.MerkleTree/UploadStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &merkleTreeUploadStreamClient{stream}
+	return x, nil
+}
+
+type MerkleTree_UploadStreamClient interface {
+	Send(*UploadStreamRequest) error
+	CloseAndRecv() (*UploadResponse, error)
+	grpc.ClientStream
+}
+
+type merkleTreeUploadStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *merkleTreeUploadStreamClient) Send(m *UploadStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *merkleTreeUploadStreamClient) CloseAndRecv() (*UploadResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *merkleTreeClient) GetByKey(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*GetByKeyResponse, error) {
+	out := new(GetByKeyResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/GetByKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) ProveAbsent(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*ProveAbsentResponse, error) {
+	out := new(ProveAbsentResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/ProveAbsent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) GetNodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error) {
+	out := new(NodesResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/GetNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *merkleTreeClient) GetTreeInfo(ctx context.Context, in *TreeInfoRequest, opts ...grpc.CallOption) (*TreeInfoResponse, error) {
+	out := new(TreeInfoResponse)
+	err := c.cc.Invoke(ctx, "/merkle_gaurd.MerkleTree/GetTreeInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MerkleTreeServer is the server API for MerkleTree service.
 // All implementations must embed UnimplementedMerkleTreeServer
 // for forward compatibility
@@ -75,6 +258,19 @@ type MerkleTreeServer interface {
 	Download(context.Context, *DownloadRequest) (*DownloadResponse, error)
 	GetMerkleProof(context.Context, *MerkleProofRequest) (*MerkleProofResponse, error)
 	VerifyMerkleProof(context.Context, *VerifyProofRequest) (*VerifyProofResponse, error)
+	AppendFiles(context.Context, *AppendFilesRequest) (*AppendFilesResponse, error)
+	GetConsistencyProof(context.Context, *ConsistencyProofRequest) (*ConsistencyProofResponse, error)
+	ReplaceFile(context.Context, *ReplaceFileRequest) (*ReplaceFileResponse, error)
+	GetMultiProof(context.Context, *MultiProofRequest) (*MultiProofResponse, error)
+	DownloadRange(*DownloadRangeRequest, MerkleTree_DownloadRangeServer) error
+	DownloadChunk(context.Context, *DownloadChunkRequest) (*DownloadChunkResponse, error)
+	GetMissingChunks(context.Context, *MissingChunksRequest) (*MissingChunksResponse, error)
+	UploadChunks(context.Context, *UploadChunksRequest) (*UploadResponse, error)
+	UploadStream(MerkleTree_UploadStreamServer) error
+	GetByKey(context.Context, *KeyRequest) (*GetByKeyResponse, error)
+	ProveAbsent(context.Context, *KeyRequest) (*ProveAbsentResponse, error)
+	GetNodes(context.Context, *NodesRequest) (*NodesResponse, error)
+	GetTreeInfo(context.Context, *TreeInfoRequest) (*TreeInfoResponse, error)
 	mustEmbedUnimplementedMerkleTreeServer()
 }
 
@@ -94,6 +290,45 @@ func (UnimplementedMerkleTreeServer) GetMerkleProof(context.Context, *MerkleProo
 func (UnimplementedMerkleTreeServer) VerifyMerkleProof(context.Context, *VerifyProofRequest) (*VerifyProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyMerkleProof not implemented")
 }
+func (UnimplementedMerkleTreeServer) AppendFiles(context.Context, *AppendFilesRequest) (*AppendFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendFiles not implemented")
+}
+func (UnimplementedMerkleTreeServer) GetConsistencyProof(context.Context, *ConsistencyProofRequest) (*ConsistencyProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsistencyProof not implemented")
+}
+func (UnimplementedMerkleTreeServer) ReplaceFile(context.Context, *ReplaceFileRequest) (*ReplaceFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceFile not implemented")
+}
+func (UnimplementedMerkleTreeServer) GetMultiProof(context.Context, *MultiProofRequest) (*MultiProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMultiProof not implemented")
+}
+func (UnimplementedMerkleTreeServer) DownloadRange(*DownloadRangeRequest, MerkleTree_DownloadRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadRange not implemented")
+}
+func (UnimplementedMerkleTreeServer) DownloadChunk(context.Context, *DownloadChunkRequest) (*DownloadChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DownloadChunk not implemented")
+}
+func (UnimplementedMerkleTreeServer) GetMissingChunks(context.Context, *MissingChunksRequest) (*MissingChunksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMissingChunks not implemented")
+}
+func (UnimplementedMerkleTreeServer) UploadChunks(context.Context, *UploadChunksRequest) (*UploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadChunks not implemented")
+}
+func (UnimplementedMerkleTreeServer) UploadStream(MerkleTree_UploadStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadStream not implemented")
+}
+func (UnimplementedMerkleTreeServer) GetByKey(context.Context, *KeyRequest) (*GetByKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByKey not implemented")
+}
+func (UnimplementedMerkleTreeServer) ProveAbsent(context.Context, *KeyRequest) (*ProveAbsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProveAbsent not implemented")
+}
+func (UnimplementedMerkleTreeServer) GetNodes(context.Context, *NodesRequest) (*NodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodes not implemented")
+}
+func (UnimplementedMerkleTreeServer) GetTreeInfo(context.Context, *TreeInfoRequest) (*TreeInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTreeInfo not implemented")
+}
 func (UnimplementedMerkleTreeServer) mustEmbedUnimplementedMerkleTreeServer() {}
 
 // UnsafeMerkleTreeServer may be embedded to opt out of forward compatibility for this service.
@@ -103,8 +338,8 @@ type UnsafeMerkleTreeServer interface {
 	mustEmbedUnimplementedMerkleTreeServer()
 }
 
-func RegisterMerkleTreeServer(s *grpc.Server, srv MerkleTreeServer) {
-	s.RegisterService(&_MerkleTree_serviceDesc, srv)
+func RegisterMerkleTreeServer(s grpc.ServiceRegistrar, srv MerkleTreeServer) {
+	s.RegisterService(&MerkleTree_ServiceDesc, srv)
 }
 
 func _MerkleTree_Upload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -179,7 +414,255 @@ func _MerkleTree_VerifyMerkleProof_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-var _MerkleTree_serviceDesc = grpc.ServiceDesc{
+func _MerkleTree_AppendFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).AppendFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/AppendFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).AppendFiles(ctx, req.(*AppendFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_GetConsistencyProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsistencyProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).GetConsistencyProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/GetConsistencyProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).GetConsistencyProof(ctx, req.(*ConsistencyProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_ReplaceFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).ReplaceFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/ReplaceFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).ReplaceFile(ctx, req.(*ReplaceFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_GetMultiProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).GetMultiProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/GetMultiProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).GetMultiProof(ctx, req.(*MultiProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_DownloadRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MerkleTreeServer).DownloadRange(m, &merkleTreeDownloadRangeServer{stream})
+}
+
+type MerkleTree_DownloadRangeServer interface {
+	Send(*DownloadRangeResponse) error
+	grpc.ServerStream
+}
+
+type merkleTreeDownloadRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *merkleTreeDownloadRangeServer) Send(m *DownloadRangeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MerkleTree_DownloadChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownloadChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).DownloadChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/DownloadChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).DownloadChunk(ctx, req.(*DownloadChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_GetMissingChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MissingChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).GetMissingChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/GetMissingChunks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).GetMissingChunks(ctx, req.(*MissingChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_UploadChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).UploadChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/UploadChunks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).UploadChunks(ctx, req.(*UploadChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_UploadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MerkleTreeServer).UploadStream(&merkleTreeUploadStreamServer{stream})
+}
+
+type MerkleTree_UploadStreamServer interface {
+	SendAndClose(*UploadResponse) error
+	Recv() (*UploadStreamRequest, error)
+	grpc.ServerStream
+}
+
+type merkleTreeUploadStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *merkleTreeUploadStreamServer) SendAndClose(m *UploadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *merkleTreeUploadStreamServer) Recv() (*UploadStreamRequest, error) {
+	m := new(UploadStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _MerkleTree_GetByKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).GetByKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/GetByKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).GetByKey(ctx, req.(*KeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_ProveAbsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).ProveAbsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/ProveAbsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).ProveAbsent(ctx, req.(*KeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_GetNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).GetNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/GetNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).GetNodes(ctx, req.(*NodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MerkleTree_GetTreeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TreeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MerkleTreeServer).GetTreeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/merkle_gaurd.MerkleTree/GetTreeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MerkleTreeServer).GetTreeInfo(ctx, req.(*TreeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MerkleTree_ServiceDesc is the grpc.ServiceDesc for MerkleTree service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MerkleTree_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "merkle_gaurd.MerkleTree",
 	HandlerType: (*MerkleTreeServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -199,7 +682,62 @@ var _MerkleTree_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyMerkleProof",
 			Handler:    _MerkleTree_VerifyMerkleProof_Handler,
 		},
+		{
+			MethodName: "AppendFiles",
+			Handler:    _MerkleTree_AppendFiles_Handler,
+		},
+		{
+			MethodName: "GetConsistencyProof",
+			Handler:    _MerkleTree_GetConsistencyProof_Handler,
+		},
+		{
+			MethodName: "ReplaceFile",
+			Handler:    _MerkleTree_ReplaceFile_Handler,
+		},
+		{
+			MethodName: "GetMultiProof",
+			Handler:    _MerkleTree_GetMultiProof_Handler,
+		},
+		{
+			MethodName: "DownloadChunk",
+			Handler:    _MerkleTree_DownloadChunk_Handler,
+		},
+		{
+			MethodName: "GetMissingChunks",
+			Handler:    _MerkleTree_GetMissingChunks_Handler,
+		},
+		{
+			MethodName: "UploadChunks",
+			Handler:    _MerkleTree_UploadChunks_Handler,
+		},
+		{
+			MethodName: "GetByKey",
+			Handler:    _MerkleTree_GetByKey_Handler,
+		},
+		{
+			MethodName: "ProveAbsent",
+			Handler:    _MerkleTree_ProveAbsent_Handler,
+		},
+		{
+			MethodName: "GetNodes",
+			Handler:    _MerkleTree_GetNodes_Handler,
+		},
+		{
+			MethodName: "GetTreeInfo",
+			Handler:    _MerkleTree_GetTreeInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadRange",
+			Handler:       _MerkleTree_DownloadRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadStream",
+			Handler:       _MerkleTree_UploadStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/proto/merkle.proto",
 }
@@ -6,13 +6,16 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/srinathln7/merkle_gaurd/internal/client"
+	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
 	"github.com/srinathln7/merkle_gaurd/lib/util"
 )
 
@@ -22,8 +25,23 @@ var (
 	fileDir     string
 	rootHashDir string
 	proofsDir   string
+	leafCount   int
+	hashAlgo    string
+	domainSep   bool
+	fileIdxs    string
+	fileName    string
+	byteRange   string
+	chunkSize   int
+	cdc         bool
+	shapeName   string
+	format      string
+	maxDepth    int
+	proofIdx    int
 )
 
+// multiProofFile is the name of the file the merkle multi-proof is stored in.
+const multiProofFile = "multiproof"
+
 func SetupFlags() {
 	RootCmd.PersistentFlags().IntVarP(&fileIdx, "fileIdx", "i", 0, "Index of the file")
 	RootCmd.PersistentFlags().StringVarP(&filesDir, "uploadDir", "d", "", "Upload files directory")
@@ -32,10 +50,35 @@ func SetupFlags() {
 	RootCmd.PersistentFlags().StringVarP(&fileDir, "downloadDir", "o", "", "File directory")
 	RootCmd.PersistentFlags().StringVarP(&fileDir, "file", "f", "", "File directory")
 	RootCmd.PersistentFlags().StringVarP(&proofsDir, "merkleProofs", "p", "", "Directory where the merkle proofs for the file is located")
+	RootCmd.PersistentFlags().StringVarP(&hashAlgo, "hashAlgorithm", "a", mt.SHA256, "Hash algorithm to build the merkle tree with ("+strings.Join(mt.HashAlgorithms(), ", ")+")")
+	RootCmd.PersistentFlags().BoolVarP(&domainSep, "domainSeparation", "s", true, "Prefix leaf and interior node hashes (RFC 6962) when building the merkle tree")
+	RootCmd.PersistentFlags().StringVarP(&fileIdxs, "fileIdxs", "I", "", "Comma separated file indices and index ranges, e.g. 0,3,10-20")
+	RootCmd.PersistentFlags().StringVarP(&fileName, "fileName", "k", "", "Name of the file, which keys it in the sparse merkle tree")
+	RootCmd.PersistentFlags().StringVarP(&byteRange, "byteRange", "b", "", "Inclusive byte range of the file, e.g. 0-1023")
+	RootCmd.PersistentFlags().IntVarP(&chunkSize, "chunkSize", "c", mt.DefaultChunkSize, "Size of the chunks files are split into when building the merkle tree (0 disables chunking)")
+	RootCmd.PersistentFlags().BoolVarP(&cdc, "contentDefinedChunking", "C", false, "Cut chunks where the content matches (FastCDC) and only upload the chunks the server does not store yet")
+	RootCmd.PersistentFlags().StringVarP(&shapeName, "shape", "S", "rfc6962", "Shape of the merkle tree ("+strings.Join(mt.ShapeNames(), ", ")+"), see the merkle package for their differences")
+	RootCmd.PersistentFlags().StringVarP(&format, "format", "F", string(mt.FormatDOT), "Format the merkle tree is exported to ("+strings.Join(mt.ExportFormats(), ", ")+")")
+	RootCmd.PersistentFlags().IntVarP(&maxDepth, "depth", "D", -1, "Deepest level of the merkle tree to export, the root being on level 0 (negative exports all levels)")
+	RootCmd.PersistentFlags().IntVarP(&proofIdx, "proofIdx", "P", -1, "Index of the file whose proof path is highlighted in the exported merkle tree (negative highlights none)")
+	RootCmd.PersistentFlags().IntVarP(&leafCount, "leafCount", "n", 0, "Number of uploaded files (only needed for merkle root hash files written by older clients)")
 	RootCmd.AddCommand(uploadCmd)
 	RootCmd.AddCommand(downloadCmd)
 	RootCmd.AddCommand(getMerkleProofsCmd)
 	RootCmd.AddCommand(verifyMerkleProofsCmd)
+	RootCmd.AddCommand(appendCmd)
+	RootCmd.AddCommand(upgradeRootCmd)
+	RootCmd.AddCommand(replaceCmd)
+	RootCmd.AddCommand(getMultiProofCmd)
+	RootCmd.AddCommand(verifyMultiProofCmd)
+	RootCmd.AddCommand(downloadRangeCmd)
+	RootCmd.AddCommand(getByKeyCmd)
+	RootCmd.AddCommand(proveAbsentCmd)
+	RootCmd.AddCommand(rootHashCmd)
+	RootCmd.AddCommand(downloadBytesCmd)
+	RootCmd.AddCommand(diffCmd)
+	RootCmd.AddCommand(inspectCmd)
+	RootCmd.AddCommand(infoCmd)
 }
 
 var RootCmd = &cobra.Command{
@@ -44,11 +87,21 @@ var RootCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 
 		color.Yellow("************************ Welcome to Merkle-Gaurd CLI *****************")
-		color.Yellow("Please use any of the following sub-commands 'upload', 'download', 'getMerkleProofs' or 'verifyMerkleProofs'")
-		color.Yellow("To upload a set of files from the directory: go run main.go upload -d <files_dir> -O <merkle_root_hash_path>`")
+		color.Yellow("Please use any of the following sub-commands 'upload', 'append', 'download', 'getMerkleProofs' or 'verifyMerkleProofs'")
+		color.Yellow("To upload a set of files from the directory: go run main.go upload -d <files_dir> -O <merkle_root_hash_path> [-a <hash_algorithm>] [-c <chunk_size>] [-C]`")
+		color.Yellow("To append the files from the directory to the uploaded ones: `go run main.go append -d <files_dir> -r <merkle_root_hash_path>`")
+		color.Yellow("To replace the file for the given file index on the server: `go run main.go replace -i <file_idx> -f <file_dir> -r <merkle_root_hash_path>`")
+		color.Yellow("To upgrade the stored merkle root hash to the server's grown tree after checking its consistency: `go run main.go upgradeRoot -r <merkle_root_hash_path>`")
+		color.Yellow("To compute the merkle root hash of a directory of any size locally and compare it with the stored one: `go run main.go rootHash -d <files_dir> [-r <merkle_root_hash_path>]`")
 		color.Yellow("To download a file for the given file index from the server to a specified path: `go run main.go download -i <file_idx> -o <download_path_file_dir>`")
-		color.Yellow("To get merkle proofs for the given file index from the server: `go run main.go getMerkleProofs -i <file_idx> -o <merkle_proof_path_dir>`")
-		color.Yellow("To verify merkle proofs for the given file `go run main.go verifyMerkleProofs -r <merkle_root_hash_path> -f <file_dir> -i <file_idx> -p <merkle_proof_path_dir>`")
+		color.Yellow("To download and verify a contiguous range of files in one pass: `go run main.go downloadRange -I <from_idx>-<to_idx> -r <merkle_root_hash_path> -o <download_path_file_dir>`")
+		color.Yellow("To download and verify a byte range of the file for the given file index chunk by chunk: `go run main.go downloadBytes -i <file_idx> -b <from>-<to> -r <merkle_root_hash_path> -o <download_path_file_dir>`")
+		color.Yellow("To download and verify the file with the given name: `go run main.go getByKey -k <file_name> -r <merkle_root_hash_path> -o <download_path_file_dir>`")
+		color.Yellow("To verify that no file with the given name is stored on the server: `go run main.go proveAbsent -k <file_name> -r <merkle_root_hash_path>`")
+		color.Yellow("To get merkle proofs for the given file index from the server: `go run main.go getMerkleProofs -i <file_idx> -o <merkle_proof_path_dir> [-r <merkle_root_hash_path>]`")
+		color.Yellow("To verify merkle proofs for the given file offline: `go run main.go verifyMerkleProofs -r <merkle_root_hash_path> -f <file_dir> -i <file_idx> -p <merkle_proof_path_dir>`")
+		color.Yellow("To get a single merkle proof for several file indices from the server: `go run main.go getMultiProof -I <file_idxs> -o <merkle_proof_path_dir>`")
+		color.Yellow("To verify the merkle multi-proof for the given files offline: `go run main.go verifyMultiProof -r <merkle_root_hash_path> -f <file_dir> -p <merkle_proof_path_dir>`")
 		color.Yellow("To exit this terminal press CTRL+C")
 
 		// Setup a signal handler to capture interrupt and termination signals
@@ -70,23 +123,218 @@ var uploadCmd = &cobra.Command{
 			log.Fatalf("error setting up grpc client %s", err.Error())
 		}
 
-		files, err := util.ReadFilesFromDir(filesDir)
+		scheme := mt.Scheme{
+			HashAlgorithm:    hashAlgo,
+			DomainSeparation: domainSep,
+			Version:          mt.VersionBinary,
+			Shape:            shape(),
+			ChunkSize:        chunkSize,
+			Chunking:         chunking(),
+		}
+
+		// Deduplicated uploads compare the chunks of all files with the server's ones up front, so only
+		// they read the whole directory into memory. Otherwise the files are streamed one at a time
+		var uploadResp *client.UploadResponse
+		if cdc {
+			names, files, err := util.ReadNamedFilesFromDir(filesDir)
+			if err != nil {
+				log.Fatal("error reading files from the directory:", err)
+			}
+			uploadResp, err = client.UploadDeduplicated(*grpcClient, names, files, scheme)
+			if err != nil {
+				log.Fatal("error during the client upload process:", err)
+			}
+		} else {
+			filePaths, err := util.ListFilesFromDir(filesDir)
+			if err != nil {
+				log.Fatal("error reading files from the directory:", err)
+			}
+			names := make([]string, len(filePaths))
+			for idx, filePath := range filePaths {
+				if names[idx], err = util.FileKey(filesDir, filePath); err != nil {
+					log.Fatal(err.Error())
+				}
+			}
+			uploadResp, err = client.UploadStream(*grpcClient, names, filePaths, scheme)
+			if err != nil {
+				log.Fatal("error during the client upload process:", err)
+			}
+		}
+
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		err = client.WriteRootRecord(rootHashFile, &client.RootRecord{
+			RootHash:       uploadResp.RootHash,
+			LeafCount:      uploadResp.LeafCount,
+			SparseRootHash: uploadResp.SparseRootHash,
+			Scheme:         uploadResp.Scheme,
+		})
+		if err != nil {
+			log.Fatal("error writing merkle root hash to the file:", err)
+		}
+
+		resJSON, err := json.Marshal(uploadResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		color.Green(string(resJSON))
+	},
+}
+
+var appendCmd = &cobra.Command{
+	Use:   "append",
+	Short: "Append a set of files to the uploaded ones and update the merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		names, files, err := util.ReadNamedFilesFromDir(filesDir)
 		if err != nil {
 			log.Fatal("error reading files from the directory:", err)
 		}
 
-		uploadResp, err := client.Upload(*grpcClient, files)
+		appendResp, err := client.AppendFiles(*grpcClient, names, files, rootRecord)
 		if err != nil {
-			log.Fatal("error during the client upload process:", err)
+			log.Fatal("error during the client append process:", err)
 		}
 
+		err = client.WriteRootRecord(rootHashFile, &client.RootRecord{
+			RootHash:       appendResp.RootHash,
+			LeafCount:      appendResp.LeafCount,
+			SparseRootHash: appendResp.SparseRootHash,
+			Scheme:         appendResp.Scheme,
+		})
+		if err != nil {
+			log.Fatal("error writing merkle root hash to the file:", err)
+		}
+
+		resJSON, err := json.Marshal(appendResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		color.Green(string(resJSON))
+	},
+}
+
+var upgradeRootCmd = &cobra.Command{
+	Use:   "upgradeRoot",
+	Short: "Upgrades the merkle root hash stored on the client's disk to the server's current one if the server only appended files since",
+	Run: func(cmd *cobra.Command, args []string) {
 		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
-		err = os.WriteFile(rootHashFile, []byte(uploadResp.RootHash), 0644)
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		consistencyResp, err := client.VerifyConsistency(*grpcClient, rootRecord)
+		if err != nil {
+			log.Fatal("error verifying the consistency of the merkle tree, keeping the stored merkle root hash:", err)
+		}
+
+		// The consistency proof says nothing about the names of appended files, so the sparse root hash is
+		// dropped from the record as soon as files were appended since
+		sparseRootHash := rootRecord.SparseRootHash
+		if consistencyResp.LeafCount != rootRecord.LeafCount {
+			sparseRootHash = ""
+		}
+
+		err = client.WriteRootRecord(rootHashFile, &client.RootRecord{
+			RootHash:       consistencyResp.RootHash,
+			LeafCount:      consistencyResp.LeafCount,
+			SparseRootHash: sparseRootHash,
+			Scheme:         consistencyResp.Scheme,
+		})
 		if err != nil {
 			log.Fatal("error writing merkle root hash to the file:", err)
 		}
 
-		resJSON, err := json.Marshal(uploadResp)
+		resJSON, err := json.Marshal(consistencyResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		color.Green(string(resJSON))
+	},
+}
+
+var replaceCmd = &cobra.Command{
+	Use:   "replace",
+	Short: "Replaces the file corresponding to the specified file index on the server and updates the merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
+		filePath := filepath.Join(fileDir, os.Getenv("FILE_PREFIX")+strconv.Itoa(fileIdx)+os.Getenv("FILE_FORMAT"))
+		file, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Fatalf("error reading the file from the file path %s", filePath)
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		// Files uploaded with names are replaced under the name given with `-k`
+		var name string
+		if rootRecord.SparseRootHash != "" {
+			name = fileKey()
+		}
+
+		replaceResp, err := client.ReplaceFile(*grpcClient, fileIdx, name, file, rootRecord)
+		if err != nil {
+			log.Fatal("error verifying the replaced file, keeping the stored merkle root hash:", err)
+		}
+
+		err = client.WriteRootRecord(rootHashFile, &client.RootRecord{
+			RootHash:       replaceResp.RootHash,
+			LeafCount:      replaceResp.LeafCount,
+			SparseRootHash: replaceResp.SparseRootHash,
+			Scheme:         replaceResp.Scheme,
+		})
+		if err != nil {
+			log.Fatal("error writing merkle root hash to the file:", err)
+		}
+
+		resJSON, err := json.Marshal(replaceResp)
 		if err != nil {
 			log.Fatal("error:", err)
 		}
@@ -131,6 +379,24 @@ var getMerkleProofsCmd = &cobra.Command{
 			return
 		}
 
+		// The proof is stored in its compact form if the number of uploaded files is known
+		proofLeafCount := leafCount
+		if rootHashDir != "" {
+			rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+			rootRecord, err := client.ReadRootRecord(rootHashFile)
+			if err != nil {
+				log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+			}
+			if rootRecord.LeafCount > 0 {
+				proofLeafCount = rootRecord.LeafCount
+			}
+		}
+		if proofLeafCount > 0 {
+			if err := proofResp.Compact(fileIdx, proofLeafCount); err != nil {
+				log.Fatalf("error compacting the merkle proofs: %v", err)
+			}
+		}
+
 		resJSON, err := json.Marshal(proofResp)
 		if err != nil {
 			log.Fatal("error:", err)
@@ -147,19 +413,21 @@ var getMerkleProofsCmd = &cobra.Command{
 
 var verifyMerkleProofsCmd = &cobra.Command{
 	Use:   "verifyMerkleProofs",
-	Short: "Outputs the merkle proofs for the file corresponding to the specified file index",
+	Short: "Verifies the merkle proofs for the downloaded file offline against the merkle root hash stored on the client's disk",
 	Run: func(cmd *cobra.Command, args []string) {
-		grpcClient, err := client.SetupGRPCClient()
-		if err != nil {
-			log.Fatalf("error setting up grpc client %s", err.Error())
-		}
-
 		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
-		rootHash, err := os.ReadFile(rootHashFile)
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
 		if err != nil {
 			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
 		}
 
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
 		filePath := filepath.Join(fileDir, os.Getenv("FILE_PREFIX")+strconv.Itoa(fileIdx)+os.Getenv("FILE_FORMAT"))
 		file, err := os.ReadFile(filePath)
 		if err != nil {
@@ -178,11 +446,104 @@ var verifyMerkleProofsCmd = &cobra.Command{
 			log.Fatal(err.Error())
 		}
 
-		verifyResp, err := client.VerifyMerkleProof(*grpcClient, client.VerifyRequest{
-			RootHash: rootHash,
-			FileIdx:  fileIdx,
-			File:     file,
-			Proofs:   proofResp.Proofs,
+		verifyResp, err := client.VerifyMerkleProofLocally(client.VerifyRequest{
+			RootHash:  []byte(rootRecord.RootHash),
+			LeafCount: rootRecord.LeafCount,
+			FileIdx:   fileIdx,
+			File:      file,
+			Proofs:    proofResp.Proofs,
+			Proof:     proofResp.Proof,
+			Scheme:    rootRecord.Scheme,
+		})
+		if err != nil {
+			return
+		}
+
+		resJSON, err := json.Marshal(verifyResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+		color.Green(string(resJSON))
+	},
+}
+
+var getMultiProofCmd = &cobra.Command{
+	Use:   "getMultiProof",
+	Short: "Outputs a single merkle proof for the files corresponding to the specified file indices",
+	Run: func(cmd *cobra.Command, args []string) {
+		idxs, err := util.ParseIndices(fileIdxs, leafCount)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		proofResp, err := client.GetMultiProof(*grpcClient, idxs)
+		if err != nil {
+			return
+		}
+
+		resJSON, err := json.Marshal(proofResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		err = util.WriteFile(fileDir, multiProofFile+os.Getenv("FILE_FORMAT"), string(resJSON))
+		if err != nil {
+			log.Fatalf("error writing merkle multi-proof to the specified path: %v", err)
+		}
+
+		color.Green(string(resJSON))
+	},
+}
+
+var verifyMultiProofCmd = &cobra.Command{
+	Use:   "verifyMultiProof",
+	Short: "Verifies the merkle multi-proof for the downloaded files offline against the merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
+		proofsFile := filepath.Join(proofsDir, multiProofFile+os.Getenv("FILE_FORMAT"))
+		proofsRespBytes, err := os.ReadFile(proofsFile)
+		if err != nil {
+			log.Fatalf("error reading the merkle multi-proof from the specified path %s", proofsFile)
+		}
+
+		var proofResp client.MultiProofResponse
+		err = json.Unmarshal(proofsRespBytes, &proofResp)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		files := make([][]byte, len(proofResp.FileIdxs))
+		for idx, fileIdx := range proofResp.FileIdxs {
+			filePath := filepath.Join(fileDir, os.Getenv("FILE_PREFIX")+strconv.Itoa(fileIdx)+os.Getenv("FILE_FORMAT"))
+			if files[idx], err = os.ReadFile(filePath); err != nil {
+				log.Fatalf("error reading the file from the file path %s", filePath)
+			}
+		}
+
+		verifyResp, err := client.VerifyMultiProofLocally(client.MultiVerifyRequest{
+			RootHash:  []byte(rootRecord.RootHash),
+			LeafCount: rootRecord.LeafCount,
+			FileIdxs:  proofResp.FileIdxs,
+			Files:     files,
+			Proofs:    proofResp.Proofs,
+			Scheme:    rootRecord.Scheme,
 		})
 		if err != nil {
 			return
@@ -195,3 +556,353 @@ var verifyMerkleProofsCmd = &cobra.Command{
 		color.Green(string(resJSON))
 	},
 }
+
+var downloadRangeCmd = &cobra.Command{
+	Use:   "downloadRange",
+	Short: "Downloads a contiguous range of files and verifies them as a whole against the merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
+		idxs, err := util.ParseIndices(fileIdxs, rootRecord.LeafCount)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		from, to := idxs[0], idxs[len(idxs)-1]
+		for idx, fileIdx := range idxs {
+			if fileIdx != from+idx {
+				log.Fatalf("file indices %s do not form a single range like 1000-1999", fileIdxs)
+			}
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		downloadResp, err := client.DownloadRange(*grpcClient, from, to, rootRecord)
+		if err != nil {
+			return
+		}
+
+		for idx, file := range downloadResp.Files {
+			err = util.WriteFile(fileDir, os.Getenv("FILE_PREFIX")+strconv.Itoa(from+idx)+os.Getenv("FILE_FORMAT"), string(file))
+			if err != nil {
+				log.Fatalf("error downloading file to the specified path: %v", err)
+			}
+		}
+
+		color.Green(downloadResp.Msg)
+	},
+}
+
+var downloadBytesCmd = &cobra.Command{
+	Use:   "downloadBytes",
+	Short: "Downloads a byte range of the file for the given file index and verifies every chunk it spans against the merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to, err := util.ParseRange(byteRange)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		if rootRecord.LeafCount == 0 {
+			rootRecord.LeafCount = leafCount
+		}
+		if rootRecord.LeafCount <= 0 {
+			log.Fatalf("number of uploaded files unknown for the merkle root hash in %s, please specify it with -n", rootHashFile)
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		downloadResp, err := client.DownloadBytes(*grpcClient, fileIdx, from, to, rootRecord)
+		if err != nil {
+			return
+		}
+
+		err = util.WriteFile(fileDir, fmt.Sprintf("%s%d_%d-%d%s", os.Getenv("FILE_PREFIX"), fileIdx, from, to, os.Getenv("FILE_FORMAT")), string(downloadResp.Bytes))
+		if err != nil {
+			log.Fatalf("error downloading file to the specified path: %v", err)
+		}
+
+		color.Green(downloadResp.Msg)
+	},
+}
+
+var getByKeyCmd = &cobra.Command{
+	Use:   "getByKey",
+	Short: "Downloads the file with the specified name and verifies it against the sparse merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		key := fileKey()
+		getResp, err := client.GetByKey(*grpcClient, key, rootRecord)
+		if err != nil {
+			return
+		}
+
+		err = util.WriteFile(filepath.Join(fileDir, filepath.FromSlash(path.Dir(key))), path.Base(key), string(getResp.File))
+		if err != nil {
+			log.Fatalf("error downloading file to the specified path: %v", err)
+		}
+
+		color.Green(getResp.Msg)
+	},
+}
+
+var proveAbsentCmd = &cobra.Command{
+	Use:   "proveAbsent",
+	Short: "Verifies that no file with the specified name is stored on the server against the sparse merkle root hash stored on the client's disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+		rootRecord, err := client.ReadRootRecord(rootHashFile)
+		if err != nil {
+			log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+		}
+
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		key := fileKey()
+		absentResp, err := client.ProveAbsent(*grpcClient, key, rootRecord)
+		if err != nil {
+			return
+		}
+
+		color.Green(absentResp.Msg)
+	},
+}
+
+var rootHashCmd = &cobra.Command{
+	Use:   "rootHash",
+	Short: "Computes the merkle root hash of a directory one file at a time and compares it with the one stored on the client's disk if specified",
+	Run: func(cmd *cobra.Command, args []string) {
+		filePaths, err := util.ListFilesFromDir(filesDir)
+		if err != nil {
+			log.Fatal("error reading files from the directory:", err)
+		}
+
+		scheme := mt.Scheme{
+			HashAlgorithm:    hashAlgo,
+			DomainSeparation: domainSep,
+			Version:          mt.VersionBinary,
+			Shape:            shape(),
+			ChunkSize:        chunkSize,
+			Chunking:         chunking(),
+		}
+
+		var rootRecord *client.RootRecord
+		if rootHashDir != "" {
+			rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+			rootRecord, err = client.ReadRootRecord(rootHashFile)
+			if err != nil {
+				log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+			}
+			scheme = rootRecord.Scheme
+		}
+
+		rootHashResp, err := client.RootHash(filePaths, scheme)
+		if err != nil {
+			log.Fatal("error computing the merkle root hash:", err)
+		}
+
+		resJSON, err := json.Marshal(rootHashResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+		color.Green(string(resJSON))
+
+		if rootRecord != nil {
+			if rootRecord.RootHash != rootHashResp.RootHash {
+				log.Fatalf("merkle root hash of the directory does not match the stored one %s", rootRecord.RootHash)
+			}
+			color.Green("merkle root hash of the directory matches the stored one")
+		}
+	},
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Lists the files of a directory that differ from the ones on the server without downloading them",
+	Run: func(cmd *cobra.Command, args []string) {
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		files, err := util.ReadFilesFromDir(filesDir)
+		if err != nil {
+			log.Fatal("error reading files from the directory:", err)
+		}
+
+		scheme := mt.Scheme{
+			HashAlgorithm:    hashAlgo,
+			DomainSeparation: domainSep,
+			Version:          mt.VersionBinary,
+			Shape:            shape(),
+			ChunkSize:        chunkSize,
+			Chunking:         chunking(),
+		}
+
+		if rootHashDir != "" {
+			rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+			rootRecord, err := client.ReadRootRecord(rootHashFile)
+			if err != nil {
+				log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+			}
+			scheme = rootRecord.Scheme
+		}
+
+		diffResp, err := client.Diff(*grpcClient, files, scheme)
+		if err != nil {
+			log.Fatal("error comparing the files with the server:", err)
+		}
+
+		resJSON, err := json.Marshal(diffResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+		color.Green(string(resJSON))
+	},
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Exports the merkle tree of a directory, or the server's one if no directory is specified, to DOT, JSON or Mermaid",
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := []mt.ExportOption{mt.WithMaxDepth(maxDepth), mt.WithProofPath(proofIdx)}
+
+		if filesDir == "" {
+			grpcClient, err := client.SetupGRPCClient()
+			if err != nil {
+				log.Fatalf("error setting up grpc client %s", err.Error())
+			}
+
+			root, err := client.FetchTree(*grpcClient, opts...)
+			if err != nil {
+				log.Fatal("error fetching the merkle tree from the server:", err)
+			}
+
+			if err := mt.Export(os.Stdout, root, mt.ExportFormat(format), opts...); err != nil {
+				log.Fatal("error exporting the merkle tree:", err)
+			}
+			return
+		}
+
+		files, err := util.ReadFilesFromDir(filesDir)
+		if err != nil {
+			log.Fatal("error reading files from the directory:", err)
+		}
+
+		scheme := mt.Scheme{
+			HashAlgorithm:    hashAlgo,
+			DomainSeparation: domainSep,
+			Version:          mt.VersionBinary,
+			Shape:            shape(),
+			ChunkSize:        chunkSize,
+			Chunking:         chunking(),
+		}
+
+		if rootHashDir != "" {
+			rootHashFile := filepath.Join(rootHashDir, os.Getenv("MERKLE_ROOT_FILE"))
+			rootRecord, err := client.ReadRootRecord(rootHashFile)
+			if err != nil {
+				log.Fatalf("error reading merkle root hash from the client's disk path %s", rootHashFile)
+			}
+			scheme = rootRecord.Scheme
+		}
+
+		schemeOpts, err := scheme.Options()
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		merkleTree, err := mt.BuildMerkleTree(files, schemeOpts...)
+		if err != nil {
+			log.Fatal("error building the merkle tree:", err)
+		}
+
+		if err := merkleTree.Export(os.Stdout, mt.ExportFormat(format), opts...); err != nil {
+			log.Fatal("error exporting the merkle tree:", err)
+		}
+	},
+}
+
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Shows the statistics of the server's merkle tree like its number of nodes, height and build duration",
+	Run: func(cmd *cobra.Command, args []string) {
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+
+		infoResp, err := client.GetTreeInfo(*grpcClient)
+		if err != nil {
+			log.Fatal("error fetching the merkle tree info from the server:", err)
+		}
+
+		resJSON, err := json.Marshal(infoResp)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+		color.Green(string(resJSON))
+	},
+}
+
+// shape returns the tree shape selected with the flags.
+func shape() mt.Shape {
+	s, err := mt.ShapeByName(shapeName)
+	if err != nil {
+		log.Fatalf("error: %v %q, use one of %s", err, shapeName, strings.Join(mt.ShapeNames(), ", "))
+	}
+	return s
+}
+
+// fileKey returns the key of the file given with `-k`, which names the file by its path relative to the
+// upload directory like `util.FileKey` does on upload.
+func fileKey() string {
+	key, err := util.FileKey(filesDir, filepath.Join(filesDir, fileName))
+	if err != nil {
+		log.Fatalf("error: %v", err)
+	}
+	return key
+}
+
+// chunking returns the chunking selected with the flags.
+func chunking() mt.Chunking {
+	if cdc {
+		return mt.ChunkingContentDefined
+	}
+	return mt.ChunkingFixed
+}
@@ -4,6 +4,7 @@ go 1.22.0
 
 require (
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/crypto v0.18.0
 	google.golang.org/grpc v1.62.1
 	google.golang.org/protobuf v1.33.0
 )
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/srinathln7/merkle_gaurd/lib/util"
@@ -40,33 +43,358 @@ func SetupGRPCClient() (*api.MerkleTreeClient, error) {
 }
 
 type UploadResponse struct {
-	Msg      string `json:"msg"`
-	RootHash string `json:"merkle_root_hash"`
+	Msg            string `json:"msg"`
+	RootHash       string `json:"merkle_root_hash"`
+	LeafCount      int    `json:"leaf_count"`
+	SparseRootHash string `json:"sparse_root_hash,omitempty"`
+	mt.Scheme
 }
 
-func Upload(grpcClient api.MerkleTreeClient, files [][]byte) (*UploadResponse, error) {
-	ctx := context.Background()
-	resp, err := grpcClient.Upload(
-		ctx,
-		&api.UploadRequest{
-			Files: files,
-		},
-	)
+// Upload uploads the files to the server. The client builds the merkle tree over the same files
+// locally using the given scheme and only accepts the upload if the server reports the very same
+// merkle root hash. The returned root hash is the locally computed one and serves as the client's trust anchor.
+func Upload(grpcClient api.MerkleTreeClient, files [][]byte, scheme mt.Scheme) (*UploadResponse, error) {
+	return UploadNamed(grpcClient, nil, files, scheme)
+}
+
+// UploadNamed uploads the files like `Upload` and additionally keys them by the given file names, which
+// lets the client fetch files by name and have the server prove that a name is absent. The sparse merkle
+// root hash is checked against a locally built sparse merkle tree just like the merkle root hash.
+func UploadNamed(grpcClient api.MerkleTreeClient, names []string, files [][]byte, scheme mt.Scheme) (*UploadResponse, error) {
+	return upload(names, files, scheme, func(apiScheme *api.Scheme) (*api.UploadResponse, error) {
+		return grpcClient.Upload(
+			context.Background(),
+			&api.UploadRequest{
+				Files:     files,
+				Scheme:    apiScheme,
+				FileNames: names,
+			},
+		)
+	})
+}
+
+// UploadDeduplicated uploads the files like `UploadNamed`, but only transfers the chunks the server does not
+// store yet. The files are split into chunks with the chunking of the scheme, whose content addresses are
+// sent to the server first. The files are then uploaded as manifests of their chunks along with the missing
+// ones, which saves the bandwidth of every chunk shared with files uploaded before, e.g. with earlier snapshots.
+// Without a chunk size every file is a single chunk, so only files uploaded before unchanged are skipped.
+func UploadDeduplicated(grpcClient api.MerkleTreeClient, names []string, files [][]byte, scheme mt.Scheme) (*UploadResponse, error) {
+	opts, err := scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	var keys []string
+	chunks := make(map[string][]byte)
+	manifests := make([]*api.FileManifest, len(files))
+	for idx, file := range files {
+		manifest := &api.FileManifest{}
+		for _, chunk := range mt.Chunks(file, opts...) {
+			key := mt.ChunkKey(chunk)
+			if _, ok := chunks[key]; !ok {
+				chunks[key] = chunk
+				keys = append(keys, key)
+			}
+			manifest.ChunkKeys = append(manifest.ChunkKeys, key)
+		}
+		manifests[idx] = manifest
+	}
+
+	var sent int
+	resp, err := upload(names, files, scheme, func(apiScheme *api.Scheme) (*api.UploadResponse, error) {
+		ctx := context.Background()
+		missingResp, err := grpcClient.GetMissingChunks(ctx, &api.MissingChunksRequest{ChunkKeys: keys})
+		if err != nil {
+			return nil, err
+		}
+
+		missing := make([][]byte, 0, len(missingResp.ChunkKeys))
+		for _, key := range missingResp.ChunkKeys {
+			if chunk, ok := chunks[key]; ok {
+				missing = append(missing, chunk)
+			}
+		}
+		sent = len(missing)
+
+		return grpcClient.UploadChunks(
+			ctx,
+			&api.UploadChunksRequest{
+				Manifests: manifests,
+				Chunks:    missing,
+				FileNames: names,
+				Scheme:    apiScheme,
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Msg = fmt.Sprintf("all files uploaded successfully, transferred %d of %d distinct chunks", sent, len(keys))
+	return resp, nil
+}
+
+// upload builds the merkle trees over the files locally, uploads them with `send` and only accepts the upload
+// if the server reports the very same root hashes.
+func upload(names []string, files [][]byte, scheme mt.Scheme, send func(*api.Scheme) (*api.UploadResponse, error)) (*UploadResponse, error) {
+	opts, err := scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	util.ClientLog("building the merkle tree locally over the files to upload")
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+	rootHash := mt.EncodeHash(merkleTree.GetMerkleRoot().Hash)
 
+	var sparseRootHash string
+	if len(names) != 0 {
+		sparseTree, err := mt.BuildSparseMerkleTree(names, files, opts...)
+		if err != nil {
+			util.ErrLog(err.Error())
+			return nil, err
+		}
+		sparseRootHash = mt.EncodeHash(sparseTree.RootHash())
+	}
+
+	resp, err := send(util.ToAPIScheme(merkleTree.Scheme()))
 	if err != nil {
 		util.ErrLog(err.Error())
 		return nil, err
 	}
 
+	if string(resp.MerkleRootHash) != rootHash {
+		err = fmt.Errorf("%w: server returned %s but the client computed %s", mterr.ErrMerkleRootHashMisMatch, resp.MerkleRootHash, rootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if string(resp.SparseRootHash) != sparseRootHash {
+		err = fmt.Errorf("%w: server returned the sparse root %s but the client computed %s", mterr.ErrMerkleRootHashMisMatch, resp.SparseRootHash, sparseRootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
 	// Store the resulting merkle root hash on client side disk - say a simple `.json` file
 	util.ClientLog("storing the merkle tree root hash on client's disk")
 
 	return &UploadResponse{
-		Msg:      "all files uploaded successfully",
-		RootHash: string(resp.MerkleRootHash),
+		Msg:            "all files uploaded successfully",
+		RootHash:       rootHash,
+		LeafCount:      len(files),
+		SparseRootHash: sparseRootHash,
+		Scheme:         merkleTree.Scheme(),
+	}, nil
+}
+
+// uploadStreamPieceSize is the largest piece of a file sent in a single message of `UploadStream`, which
+// keeps the messages well below the default message size limit of gRPC.
+const uploadStreamPieceSize = 1 << 20
+
+// UploadStream uploads the files at the given paths like `UploadNamed`, but streams them to the server one
+// after another rather than sending them all in a single message. Every file is read through a buffer of
+// `uploadStreamPieceSize` bytes and hashed piece by piece while it is sent, so no file is ever held in memory
+// as a whole: the client computes the merkle root hash with a `Builder` over the leaves of the files, and the
+// server hashes and stores the chunks of every file as its pieces arrive. Names are optional like for `Upload`.
+func UploadStream(grpcClient api.MerkleTreeClient, names []string, filePaths []string, scheme mt.Scheme) (*UploadResponse, error) {
+	switch {
+	case len(filePaths) == 0:
+		util.ErrLog(mterr.ErrEmptyFile.Error())
+		return nil, mterr.ErrEmptyFile
+	case len(names) != 0 && len(names) != len(filePaths):
+		util.ErrLog(mterr.ErrMissingFileNames.Error())
+		return nil, mterr.ErrMissingFileNames
+	}
+
+	opts, err := scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	builder, err := mt.NewBuilder(len(filePaths), opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	var sparseTree *mt.SparseMerkleTree
+	if len(names) != 0 {
+		sparseTree = mt.NewSparseMerkleTree(opts...)
+	}
+
+	// Cancelling the stream on an error makes the server discard the files received so far
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := grpcClient.UploadStream(ctx)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	apiScheme := util.ToAPIScheme(builder.Scheme())
+	seen := make(map[string]bool, len(names))
+	for idx, filePath := range filePaths {
+		var name string
+		if sparseTree != nil {
+			if name = names[idx]; seen[name] {
+				util.ErrLog(mterr.ErrDuplicateFileName.Error())
+				return nil, mterr.ErrDuplicateFileName
+			}
+			seen[name] = true
+		}
+
+		leaf, valueHash, err := streamFile(stream, filePath, name, apiScheme, opts)
+		if err != nil {
+			util.ErrLog(err.Error())
+			return nil, err
+		}
+		if err := builder.AddLeaf(leaf); err != nil {
+			util.ErrLog(err.Error())
+			return nil, err
+		}
+		if sparseTree != nil {
+			sparseTree.PutValueHash(name, valueHash)
+		}
+		apiScheme = nil
+	}
+
+	rootHash, err := builder.Finish()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if string(resp.MerkleRootHash) != mt.EncodeHash(rootHash) {
+		err = fmt.Errorf("%w: server returned %s but the client computed %s", mterr.ErrMerkleRootHashMisMatch, resp.MerkleRootHash, mt.EncodeHash(rootHash))
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	var sparseRootHash string
+	if sparseTree != nil {
+		sparseRootHash = mt.EncodeHash(sparseTree.RootHash())
+	}
+	if string(resp.SparseRootHash) != sparseRootHash {
+		err = fmt.Errorf("%w: server returned the sparse root %s but the client computed %s", mterr.ErrMerkleRootHashMisMatch, resp.SparseRootHash, sparseRootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &UploadResponse{
+		Msg:            fmt.Sprintf("%d files streamed successfully", len(filePaths)),
+		RootHash:       mt.EncodeHash(rootHash),
+		LeafCount:      len(filePaths),
+		SparseRootHash: sparseRootHash,
+		Scheme:         builder.Scheme(),
+	}, nil
+}
+
+// streamFile sends the file at the given path in pieces of at most `uploadStreamPieceSize` bytes, the first
+// of which carries the name and the scheme, and returns the digest of its leaf and its sparse value hash, which
+// are computed from the pieces as they are sent. A file ending right after a full piece is completed by an
+// empty one, and empty files are sent as a single empty piece.
+func streamFile(stream api.MerkleTree_UploadStreamClient, filePath, name string, scheme *api.Scheme, opts []mt.Option) (leaf, valueHash []byte, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	fileHasher := mt.NewFileHasher(nil, opts...)
+	for endOfFile := false; !endOfFile; {
+		// gRPC may still hold a sent message, so every piece gets its own buffer
+		piece := make([]byte, uploadStreamPieceSize)
+		n, err := io.ReadFull(file, piece)
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			endOfFile = true
+		default:
+			return nil, nil, err
+		}
+
+		fileHasher.Write(piece[:n])
+		err = stream.Send(&api.UploadStreamRequest{
+			Scheme:    scheme,
+			FileName:  name,
+			Content:   piece[:n],
+			EndOfFile: endOfFile,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		scheme, name = nil, ""
+	}
+
+	leaf, valueHash = fileHasher.Finish()
+	return leaf, valueHash, nil
+}
+
+type RootHashResponse struct {
+	Msg       string `json:"msg"`
+	RootHash  string `json:"merkle_root_hash"`
+	LeafCount int    `json:"leaf_count"`
+	mt.Scheme
+}
+
+// RootHash computes the merkle root hash over the files at the given paths without uploading them. The files
+// are streamed one at a time, so neither they nor the tree are ever held in memory as a whole.
+func RootHash(filePaths []string, scheme mt.Scheme) (*RootHashResponse, error) {
+	opts, err := scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	builder, err := mt.NewBuilder(len(filePaths), opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	for _, filePath := range filePaths {
+		if err := addFile(builder, filePath); err != nil {
+			util.ErrLog(err.Error())
+			return nil, err
+		}
+	}
+
+	rootHash, err := builder.Finish()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &RootHashResponse{
+		Msg:       fmt.Sprintf("merkle root hash computed over %d files", len(filePaths)),
+		RootHash:  mt.EncodeHash(rootHash),
+		LeafCount: len(filePaths),
+		Scheme:    builder.Scheme(),
 	}, nil
 }
 
+// addFile streams the file at the given path into the builder.
+func addFile(builder *mt.Builder, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return builder.Add(file)
+}
+
 type DownloadResponse struct {
 	Msg  string `json:"msg"`
 	File []byte `json:"file_content"`
@@ -93,17 +421,33 @@ func Download(grpcClient api.MerkleTreeClient, fileIdx int) (*DownloadResponse,
 	}, nil
 }
 
-type ProofResponse struct {
-	Msg    string          `json:"msg"`
-	Proofs []*api.TreeNode `json:"proofs"`
+type DownloadRangeResponse struct {
+	Msg   string   `json:"msg"`
+	Files [][]byte `json:"file_contents"`
 }
 
-func GetMerkleProof(grpcClient api.MerkleTreeClient, fileIdx int) (*ProofResponse, error) {
+// DownloadRange downloads the files `[from, to]` in a single stream. The server sends the range proof
+// after the last file, and the files are only returned if the proof verifies them as a whole against
+// the given root record.
+func DownloadRange(grpcClient api.MerkleTreeClient, from, to int, record *RootRecord) (*DownloadRangeResponse, error) {
+	opts, err := record.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	rootHash, err := mt.DecodeHash(record.RootHash)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
 	ctx := context.Background()
-	resp, err := grpcClient.GetMerkleProof(
+	stream, err := grpcClient.DownloadRange(
 		ctx,
-		&api.MerkleProofRequest{
-			FileIndex: int64(fileIdx),
+		&api.DownloadRangeRequest{
+			FromIndex: int64(from),
+			ToIndex:   int64(to),
 		},
 	)
 
@@ -112,34 +456,91 @@ func GetMerkleProof(grpcClient api.MerkleTreeClient, fileIdx int) (*ProofRespons
 		return nil, err
 	}
 
-	msg := fmt.Sprintf("merkle proofs for file%d generated successfully\n", fileIdx)
-	return &ProofResponse{
-		Msg:    msg,
-		Proofs: resp.Proofs,
+	var files [][]byte
+	var rangeProof *api.RangeProof
+	for rangeProof == nil {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			err = fmt.Errorf("%w: stream ended without a range proof", mterr.ErrInvalidProof)
+		}
+		if err != nil {
+			util.ErrLog(err.Error())
+			return nil, err
+		}
+
+		switch payload := resp.Payload.(type) {
+		case *api.DownloadRangeResponse_FileContent:
+			files = append(files, payload.FileContent)
+		case *api.DownloadRangeResponse_RangeProof:
+			rangeProof = payload.RangeProof
+		}
+	}
+
+	if len(files) != to-from+1 {
+		err = fmt.Errorf("%w: server sent %d files for the range [%d, %d]", mterr.ErrMerkleVerificationFail, len(files), from, to)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !util.ToMerkleScheme(rangeProof.Scheme).Equal(record.Scheme) {
+		return nil, mterr.ErrSchemeMisMatch
+	}
+
+	if int(rangeProof.LeafCount) != record.LeafCount {
+		err = fmt.Errorf("%w: server reported %d files but the client recorded %d", mterr.ErrLeafCountMisMatch, rangeProof.LeafCount, record.LeafCount)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs, err := toMerkleNodes(rangeProof.Proofs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	isVerified, err := mt.VerifyRangeProof(rootHash, from, files, record.LeafCount, proofs, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	msg := fmt.Sprintf("files%d-%d downloaded and verified successfully", from, to)
+	return &DownloadRangeResponse{
+		Msg:   msg,
+		Files: files,
 	}, nil
 }
 
-type VerifyRequest struct {
-	RootHash []byte          `json:"root_hash"`
-	FileIdx  int             `json:"file_idx"`
-	File     []byte          `json:"file"`
-	Proofs   []*api.TreeNode `json:"proofs"`
+type DownloadChunkResponse struct {
+	Msg        string
+	Chunk      []byte
+	ChunkCount int
 }
 
-type VerifyResponse struct {
-	Msg       string `json:"msg"`
-	IsVerfied bool   `json:"is_Verified"`
-}
+// DownloadChunk downloads the chunk at `chunkIdx` of the file at `fileIdx` and verifies it against the recorded root hash.
+func DownloadChunk(grpcClient api.MerkleTreeClient, fileIdx, chunkIdx int, record *RootRecord) (*DownloadChunkResponse, error) {
+	opts, err := record.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	rootHash, err := mt.DecodeHash(record.RootHash)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
 
-func VerifyMerkleProof(grpcClient api.MerkleTreeClient, req VerifyRequest) (*VerifyResponse, error) {
 	ctx := context.Background()
-	resp, err := grpcClient.VerifyMerkleProof(
+	resp, err := grpcClient.DownloadChunk(
 		ctx,
-		&api.VerifyProofRequest{
-			RootHash:  req.RootHash,
-			FileIndex: int64(req.FileIdx),
-			FileHash:  []byte(mt.CalcHash(req.File)),
-			Proofs:    req.Proofs,
+		&api.DownloadChunkRequest{
+			FileIndex:  int64(fileIdx),
+			ChunkIndex: int64(chunkIdx),
 		},
 	)
 
@@ -148,13 +549,941 @@ func VerifyMerkleProof(grpcClient api.MerkleTreeClient, req VerifyRequest) (*Ver
 		return nil, err
 	}
 
-	if !resp.IsVerified {
+	if !util.ToMerkleScheme(resp.Scheme).Equal(record.Scheme) {
+		return nil, mterr.ErrSchemeMisMatch
+	}
+
+	if int(resp.LeafCount) != record.LeafCount {
+		err = fmt.Errorf("%w: server reported %d files but the client recorded %d", mterr.ErrLeafCountMisMatch, resp.LeafCount, record.LeafCount)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	chunkProofs, err := toMerkleNodes(resp.ChunkProofs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	fileProofs, err := toMerkleNodes(resp.FileProofs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	chunkCount := int(resp.ChunkCount)
+	isVerified, err := mt.VerifyChunkProof(rootHash, resp.ChunkContent, chunkIdx, chunkCount, chunkProofs, fileIdx, record.LeafCount, fileProofs, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
 		return nil, mterr.ErrMerkleVerificationFail
 	}
 
-	msg := fmt.Sprintf("merkle verification for file%d is successful", req.FileIdx)
-	return &VerifyResponse{
-		Msg:       msg,
-		IsVerfied: true,
+	msg := fmt.Sprintf("chunk%d of file%d downloaded and verified successfully", chunkIdx, fileIdx)
+	return &DownloadChunkResponse{
+		Msg:        msg,
+		Chunk:      resp.ChunkContent,
+		ChunkCount: chunkCount,
+	}, nil
+}
+
+type DownloadBytesResponse struct {
+	Msg   string
+	Bytes []byte
+}
+
+// DownloadBytes downloads the inclusive byte range [from, to] of the file at `fileIdx`, verifying every chunk
+// it overlaps against the recorded root hash. The range is cut short at the end of the file.
+func DownloadBytes(grpcClient api.MerkleTreeClient, fileIdx, from, to int, record *RootRecord) (*DownloadBytesResponse, error) {
+	if from < 0 || to < from {
+		util.ErrLog(mterr.ErrInvalidRange.Error())
+		return nil, mterr.ErrInvalidRange
+	}
+
+	// Files uploaded without chunking consist of a single chunk. The offsets of content-defined
+	// chunks are unknown up front, so those are downloaded from the start of the file
+	chunkIdx, offset := 0, 0
+	if record.Scheme.ChunkSize > 0 && record.Scheme.Chunking != mt.ChunkingContentDefined {
+		chunkIdx = from / record.Scheme.ChunkSize
+		offset = chunkIdx * record.Scheme.ChunkSize
+	}
+
+	var data []byte
+	for {
+		resp, err := DownloadChunk(grpcClient, fileIdx, chunkIdx, record)
+		if err != nil {
+			return nil, err
+		}
+
+		// Drop the chunks before the range, which are only downloaded to learn the offsets
+		if len(data) == 0 && offset+len(resp.Chunk) <= from && chunkIdx < resp.ChunkCount-1 {
+			offset += len(resp.Chunk)
+			chunkIdx++
+			continue
+		}
+		data = append(data, resp.Chunk...)
+
+		chunkIdx++
+		if offset+len(data) > to || chunkIdx == resp.ChunkCount {
+			break
+		}
+	}
+
+	if from >= offset+len(data) {
+		err := fmt.Errorf("%w: file%d has %d bytes", mterr.ErrInvalidRange, fileIdx, offset+len(data))
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("bytes %d-%d of file%d downloaded and verified successfully", from, to, fileIdx)
+	return &DownloadBytesResponse{
+		Msg:   msg,
+		Bytes: data[from-offset : min(to+1-offset, len(data))],
 	}, nil
 }
+
+type ProofResponse struct {
+	Msg    string          `json:"msg"`
+	Proofs []*api.TreeNode `json:"proofs,omitempty"`
+	Proof  *mt.Proof       `json:"proof,omitempty"`
+	mt.Scheme
+}
+
+// Compact replaces the proof path of the response by its compact form for a tree of `leafCount` files,
+// which is embedded into JSON as base64.
+func (resp *ProofResponse) Compact(fileIdx, leafCount int) error {
+	proofs, err := toMerkleNodes(resp.Proofs)
+	if err != nil {
+		return err
+	}
+
+	proof, err := mt.NewProof(fileIdx, leafCount, proofs)
+	if err != nil {
+		return err
+	}
+	resp.Proofs, resp.Proof = nil, proof
+	return nil
+}
+
+func GetMerkleProof(grpcClient api.MerkleTreeClient, fileIdx int) (*ProofResponse, error) {
+	ctx := context.Background()
+	resp, err := grpcClient.GetMerkleProof(
+		ctx,
+		&api.MerkleProofRequest{
+			FileIndex: int64(fileIdx),
+		},
+	)
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("merkle proofs for file%d generated successfully\n", fileIdx)
+	return &ProofResponse{
+		Msg:    msg,
+		Proofs: resp.Proofs,
+		Scheme: util.ToMerkleScheme(resp.Scheme),
+	}, nil
+}
+
+type MultiProofResponse struct {
+	Msg      string          `json:"msg"`
+	FileIdxs []int           `json:"file_idxs"`
+	Proofs   []*api.TreeNode `json:"proofs"`
+	mt.Scheme
+}
+
+// GetMultiProof fetches a single merkle proof for several files at once.
+func GetMultiProof(grpcClient api.MerkleTreeClient, fileIdxs []int) (*MultiProofResponse, error) {
+	fileIndices := make([]int64, len(fileIdxs))
+	for idx, fileIdx := range fileIdxs {
+		fileIndices[idx] = int64(fileIdx)
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.GetMultiProof(
+		ctx,
+		&api.MultiProofRequest{
+			FileIndices: fileIndices,
+		},
+	)
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("merkle multi-proof for %d files generated successfully\n", len(fileIdxs))
+	return &MultiProofResponse{
+		Msg:      msg,
+		FileIdxs: fileIdxs,
+		Proofs:   resp.Proofs,
+		Scheme:   util.ToMerkleScheme(resp.Scheme),
+	}, nil
+}
+
+type VerifyRequest struct {
+	RootHash  []byte          `json:"root_hash"`
+	LeafCount int             `json:"leaf_count"`
+	FileIdx   int             `json:"file_idx"`
+	File      []byte          `json:"file"`
+	Proofs    []*api.TreeNode `json:"proofs"`
+	Proof     *mt.Proof       `json:"proof,omitempty"`
+	mt.Scheme
+}
+
+type VerifyResponse struct {
+	Msg       string `json:"msg"`
+	IsVerfied bool   `json:"is_Verified"`
+}
+
+func VerifyMerkleProof(grpcClient api.MerkleTreeClient, req VerifyRequest) (*VerifyResponse, error) {
+	opts, err := req.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.VerifyMerkleProof(
+		ctx,
+		&api.VerifyProofRequest{
+			RootHash:  req.RootHash,
+			FileIndex: int64(req.FileIdx),
+			FileHash:  []byte(mt.EncodeHash(mt.LeafHash(req.File, opts...))),
+			Proofs:    req.Proofs,
+			Scheme:    util.ToAPIScheme(req.Scheme),
+		},
+	)
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !resp.IsVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	msg := fmt.Sprintf("merkle verification for file%d is successful", req.FileIdx)
+	return &VerifyResponse{
+		Msg:       msg,
+		IsVerfied: true,
+	}, nil
+}
+
+// VerifyMerkleProofLocally verifies the merkle proof of a downloaded file on the client side without
+// contacting the server. Only the root hash and the leaf count the client persisted at upload time are trusted.
+func VerifyMerkleProofLocally(req VerifyRequest) (*VerifyResponse, error) {
+	opts, err := req.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	rootHash, err := mt.DecodeHash(string(req.RootHash))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	var isVerified bool
+	if req.Proof != nil {
+		isVerified, err = mt.VerifyCompactProof(rootHash, req.File, req.FileIdx, req.LeafCount, req.Proof, opts...)
+	} else {
+		var proofs []*mt.TreeNode
+		proofs, err = toMerkleNodes(req.Proofs)
+		if err == nil {
+			isVerified, err = mt.VerifyProof(rootHash, req.File, req.FileIdx, req.LeafCount, proofs, opts...)
+		}
+	}
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	msg := fmt.Sprintf("merkle verification for file%d is successful", req.FileIdx)
+	return &VerifyResponse{
+		Msg:       msg,
+		IsVerfied: true,
+	}, nil
+}
+
+type AppendResponse struct {
+	Msg            string `json:"msg"`
+	RootHash       string `json:"merkle_root_hash"`
+	LeafCount      int    `json:"leaf_count"`
+	SparseRootHash string `json:"sparse_root_hash,omitempty"`
+	mt.Scheme
+}
+
+// AppendFiles appends the files to the ones uploaded under the given root record. The server has to
+// keep the scheme of the record and report exactly `record.LeafCount + len(files)` files, otherwise the
+// append is refused. The server returns the peaks of the recorded tree, which have to bag into the
+// recorded root hash. The new root hash is recomputed from them and the appended files and has to match
+// the server's one, so the server can neither alter the uploaded files nor append other files unnoticed.
+// Only trees with the RFC 6962, mountain range or Bitcoin shape have peaks. The returned root hashes and
+// leaf count replace the ones of the record.
+// The file names are required if the files were uploaded with names and are ignored otherwise.
+func AppendFiles(grpcClient api.MerkleTreeClient, names []string, files [][]byte, record *RootRecord) (*AppendResponse, error) {
+	opts, err := record.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.AppendFiles(
+		ctx,
+		&api.AppendFilesRequest{
+			Files:             files,
+			Scheme:            util.ToAPIScheme(record.Scheme),
+			FileNames:         names,
+			OldMerkleRootHash: []byte(record.RootHash),
+			OldLeafCount:      int64(record.LeafCount),
+		},
+	)
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	scheme := util.ToMerkleScheme(resp.Scheme)
+	if !scheme.Equal(record.Scheme) {
+		err = fmt.Errorf("%w: server built the merkle tree with %+v", mterr.ErrSchemeMisMatch, scheme)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	leafCount := record.LeafCount + len(files)
+	if int(resp.LeafCount) != leafCount {
+		err = fmt.Errorf("%w: server reported %d files but the client expected %d", mterr.ErrLeafCountMisMatch, resp.LeafCount, leafCount)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	rootHash, err := appendedRootHash(record, resp.OldPeaks, files, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+	if string(resp.MerkleRootHash) != rootHash {
+		err = fmt.Errorf("%w: server reported %s but the appended files yield %s", mterr.ErrMerkleRootHashMisMatch, resp.MerkleRootHash, rootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	sparseRootHash, err := updatedSparseRootHash(record, names, files, resp.SparseProofs, false, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+	if sparseRootHash != "" && string(resp.SparseRootHash) != sparseRootHash {
+		err = fmt.Errorf("%w: server reported the sparse root %s but the appended files yield %s", mterr.ErrMerkleRootHashMisMatch, resp.SparseRootHash, sparseRootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &AppendResponse{
+		Msg:            fmt.Sprintf("%d files appended successfully", len(files)),
+		RootHash:       rootHash,
+		LeafCount:      leafCount,
+		SparseRootHash: sparseRootHash,
+		Scheme:         record.Scheme.Normalize(),
+	}, nil
+}
+
+// appendedRootHash verifies that the peaks bag into the recorded root hash and returns the hexadecimal
+// root hash of the recorded tree with the files appended.
+func appendedRootHash(record *RootRecord, peaks [][]byte, files [][]byte, opts ...mt.Option) (string, error) {
+	digests := make([][]byte, len(peaks))
+	for idx, peak := range peaks {
+		digest, err := mt.DecodeHash(string(peak))
+		if err != nil {
+			return "", err
+		}
+		digests[idx] = digest
+	}
+
+	builder, err := mt.ResumeBuilder(record.LeafCount, digests, opts...)
+	if err != nil {
+		return "", err
+	}
+	oldRootHash, err := builder.Finish()
+	if err != nil {
+		return "", err
+	}
+	if mt.EncodeHash(oldRootHash) != record.RootHash {
+		return "", fmt.Errorf("%w: the peaks of the server's merkle tree do not yield the recorded root hash", mterr.ErrMerkleVerificationFail)
+	}
+
+	for _, file := range files {
+		if err := builder.Add(bytes.NewReader(file)); err != nil {
+			return "", err
+		}
+	}
+	rootHash, err := builder.Finish()
+	if err != nil {
+		return "", err
+	}
+	return mt.EncodeHash(rootHash), nil
+}
+
+// updatedSparseRootHash verifies the proofs of the file names against the recorded sparse root hash, each one
+// against the root with the files before it put under their names, and returns the hexadecimal sparse root
+// hash with all files put under their names. The names have to be stored already if `replace` is set and
+// must not be stored otherwise. Records without a sparse root hash yield an empty one, as the files were
+// either uploaded without names or the sparse root hash cannot be verified.
+func updatedSparseRootHash(record *RootRecord, names []string, files [][]byte, proofs []*api.SparseProof, replace bool, opts ...mt.Option) (string, error) {
+	if record.SparseRootHash == "" {
+		return "", nil
+	}
+	if len(proofs) != len(files) || len(names) != len(files) {
+		return "", fmt.Errorf("%w: server sent %d sparse proofs for %d files", mterr.ErrInvalidProof, len(proofs), len(files))
+	}
+
+	rootHash, err := mt.DecodeHash(record.SparseRootHash)
+	if err != nil {
+		return "", err
+	}
+
+	for idx, name := range names {
+		proof, err := toSparseProof(proofs[idx])
+		if err != nil {
+			return "", err
+		}
+
+		switch stored := bytes.Equal(proof.LeafKey, mt.SparseKey(name, opts...)); {
+		case replace && !stored:
+			return "", fmt.Errorf("%w: %s", mterr.ErrFileNameNotFound, name)
+		case !replace && stored:
+			return "", fmt.Errorf("%w: %s", mterr.ErrFileNameExists, name)
+		}
+
+		if rootHash, err = mt.UpdateSparseRoot(rootHash, name, files[idx], proof, opts...); err != nil {
+			return "", err
+		}
+	}
+	return mt.EncodeHash(rootHash), nil
+}
+
+type ConsistencyResponse struct {
+	Msg       string `json:"msg"`
+	RootHash  string `json:"merkle_root_hash"`
+	LeafCount int    `json:"leaf_count"`
+	mt.Scheme
+}
+
+// VerifyConsistency fetches the current merkle root hash from the server together with a consistency
+// proof against the given root record and verifies it locally. The new root hash and leaf count are only
+// returned if the server merely appended files since the record was written, so the caller can safely
+// replace the record with them.
+func VerifyConsistency(grpcClient api.MerkleTreeClient, record *RootRecord) (*ConsistencyResponse, error) {
+	opts, err := record.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	oldRootHash, err := mt.DecodeHash(record.RootHash)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.GetConsistencyProof(
+		ctx,
+		&api.ConsistencyProofRequest{
+			OldLeafCount: int64(record.LeafCount),
+		},
+	)
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	scheme := util.ToMerkleScheme(resp.Scheme)
+	if !scheme.Equal(record.Scheme) {
+		err = fmt.Errorf("%w: server built the merkle tree with %+v", mterr.ErrSchemeMisMatch, scheme)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	newRootHash, err := mt.DecodeHash(string(resp.MerkleRootHash))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs, err := toMerkleNodes(resp.Proofs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	isVerified, err := mt.VerifyConsistencyProof(oldRootHash, newRootHash, record.LeafCount, int(resp.LeafCount), proofs, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	return &ConsistencyResponse{
+		Msg:       fmt.Sprintf("merkle tree with %d files is consistent with the recorded one with %d files", resp.LeafCount, record.LeafCount),
+		RootHash:  string(resp.MerkleRootHash),
+		LeafCount: int(resp.LeafCount),
+		Scheme:    record.Scheme.Normalize(),
+	}, nil
+}
+
+type DiffResponse struct {
+	Msg            string `json:"msg"`
+	FileIdxs       []int  `json:"file_idxs"`
+	LeafCount      int    `json:"leaf_count"`
+	RequestedNodes int    `json:"requested_nodes"`
+	mt.Scheme
+}
+
+// Diff finds the files that differ from the ones the server holds at the same indices without downloading
+// any of them. The merkle tree over the local files is compared with the server's tree level by level from
+// the root, and only the nodes below differing nodes are requested, so the number of requested nodes grows
+// with the number of differing files rather than with the number of files.
+func Diff(grpcClient api.MerkleTreeClient, files [][]byte, scheme mt.Scheme) (*DiffResponse, error) {
+	opts, err := scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	requested := 0
+	getServerNodes := getNodes(grpcClient, scheme, len(files))
+	fileIdxs, err := mt.DiffLevels(merkleTree, func(level, from, to int) ([]*mt.TreeNode, error) {
+		nodes, err := getServerNodes(level, from, to)
+		requested += len(nodes)
+		return nodes, err
+	})
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &DiffResponse{
+		Msg:            fmt.Sprintf("%d of %d files differ from the ones on the server", len(fileIdxs), len(files)),
+		FileIdxs:       fileIdxs,
+		LeafCount:      len(files),
+		RequestedNodes: requested,
+		Scheme:         merkleTree.Scheme(),
+	}, nil
+}
+
+// FetchTree fetches the nodes of the server's merkle tree an export with the given options covers, e.g. the
+// levels down to a depth limit, level by level. The nodes are meant for inspection and are not verified.
+func FetchTree(grpcClient api.MerkleTreeClient, opts ...mt.ExportOption) (*mt.TreeNode, error) {
+	ctx := context.Background()
+	resp, err := grpcClient.GetNodes(ctx, &api.NodesRequest{})
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	scheme, leafCount := util.ToMerkleScheme(resp.Scheme), int(resp.LeafCount)
+	root, err := mt.FetchTree(getNodes(grpcClient, scheme, leafCount), leafCount, scheme, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+	return root, nil
+}
+
+// getNodes returns a function requesting nodes of a level of the server's merkle tree with `GetNodes`.
+// Nodes of a tree built with another scheme or over another number of files are refused.
+func getNodes(grpcClient api.MerkleTreeClient, scheme mt.Scheme, leafCount int) func(level, from, to int) ([]*mt.TreeNode, error) {
+	return func(level, from, to int) ([]*mt.TreeNode, error) {
+		resp, err := grpcClient.GetNodes(
+			context.Background(),
+			&api.NodesRequest{
+				Level:     int64(level),
+				FromIndex: int64(from),
+				ToIndex:   int64(to),
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if serverScheme := util.ToMerkleScheme(resp.Scheme); !serverScheme.Equal(scheme) {
+			return nil, fmt.Errorf("%w: server built the merkle tree with %+v", mterr.ErrSchemeMisMatch, serverScheme)
+		}
+		if int(resp.LeafCount) != leafCount {
+			return nil, fmt.Errorf("%w: server holds %d files", mterr.ErrLeafCountMisMatch, resp.LeafCount)
+		}
+		return toMerkleNodes(resp.Nodes)
+	}
+}
+
+type TreeInfoResponse struct {
+	Msg      string   `json:"msg"`
+	RootHash string   `json:"merkle_root_hash"`
+	Stats    mt.Stats `json:"stats"`
+	mt.Scheme
+}
+
+// GetTreeInfo returns the statistics of the server's merkle tree, like its number of nodes, its height and
+// the time the server took to build it, without transferring any of its nodes.
+func GetTreeInfo(grpcClient api.MerkleTreeClient) (*TreeInfoResponse, error) {
+	resp, err := grpcClient.GetTreeInfo(context.Background(), &api.TreeInfoRequest{})
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &TreeInfoResponse{
+		Msg:      "merkle tree info fetched successfully",
+		RootHash: string(resp.MerkleRootHash),
+		Stats: mt.Stats{
+			LeafCount:     int(resp.LeafCount),
+			NodeCount:     int(resp.NodeCount),
+			Height:        int(resp.Height),
+			TotalBytes:    int(resp.TotalBytes),
+			HashAlgorithm: resp.HashAlgorithm,
+			BuildDuration: time.Duration(resp.BuildDurationNs),
+		},
+		Scheme: util.ToMerkleScheme(resp.Scheme),
+	}, nil
+}
+
+type ReplaceResponse struct {
+	Msg            string `json:"msg"`
+	RootHash       string `json:"merkle_root_hash"`
+	LeafCount      int    `json:"leaf_count"`
+	SparseRootHash string `json:"sparse_root_hash,omitempty"`
+	mt.Scheme
+}
+
+// ReplaceFile replaces the file at the given index on the server. The server reports the old and the
+// new merkle root hash together with the proof path of the replaced file. The new root hash is only
+// returned if the old one is the recorded one and the proof path leads from the replaced file to the
+// old root and from the new file to the new root, i.e. if no other file changed along with it. Files
+// uploaded with names need the name of the file at the index, which the sparse merkle root hash is updated
+// under. The client relies on its own name rather than the one the server reports.
+func ReplaceFile(grpcClient api.MerkleTreeClient, fileIdx int, name string, file []byte, record *RootRecord) (*ReplaceResponse, error) {
+	opts, err := record.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if (record.SparseRootHash != "") != (name != "") {
+		err = mterr.ErrMissingFileNames
+		if name != "" {
+			err = mterr.ErrNotKeyed
+		}
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.ReplaceFile(
+		ctx,
+		&api.ReplaceFileRequest{
+			FileIndex:   int64(fileIdx),
+			FileContent: file,
+			Scheme:      util.ToAPIScheme(record.Scheme),
+			FileName:    name,
+		},
+	)
+
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if string(resp.OldMerkleRootHash) != record.RootHash {
+		err = fmt.Errorf("%w: server replaced the file in the tree with root %s but the client recorded %s", mterr.ErrMerkleRootHashMisMatch, resp.OldMerkleRootHash, record.RootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if int(resp.LeafCount) != record.LeafCount {
+		err = fmt.Errorf("%w: server reported %d files but the client recorded %d", mterr.ErrLeafCountMisMatch, resp.LeafCount, record.LeafCount)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if resp.FileName != name {
+		err = fmt.Errorf("%w: server replaced the file %q but the client named %q", mterr.ErrFileNameNotFound, resp.FileName, name)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	oldRootHash, err := mt.DecodeHash(string(resp.OldMerkleRootHash))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	newRootHash, err := mt.DecodeHash(string(resp.NewMerkleRootHash))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	oldFileHash, err := mt.DecodeHash(string(resp.OldFileHash))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs, err := toMerkleNodes(resp.Proofs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	isVerified, err := mt.VerifyUpdateProof(oldRootHash, newRootHash, oldFileHash, file, fileIdx, record.LeafCount, proofs, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	sparseRootHash, err := updatedSparseRootHash(record, []string{name}, [][]byte{file}, []*api.SparseProof{resp.SparseProof}, true, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+	if sparseRootHash != "" && string(resp.NewSparseRootHash) != sparseRootHash {
+		err = fmt.Errorf("%w: server reported the sparse root %s but the replaced file yields %s", mterr.ErrMerkleRootHashMisMatch, resp.NewSparseRootHash, sparseRootHash)
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &ReplaceResponse{
+		Msg:            fmt.Sprintf("file%d replaced successfully", fileIdx),
+		RootHash:       string(resp.NewMerkleRootHash),
+		LeafCount:      record.LeafCount,
+		SparseRootHash: sparseRootHash,
+		Scheme:         record.Scheme.Normalize(),
+	}, nil
+}
+
+type GetByKeyResponse struct {
+	Msg     string `json:"msg"`
+	FileIdx int    `json:"file_index"`
+	File    []byte `json:"file_content"`
+}
+
+// GetByKey downloads the file stored under the given file name. The file is only returned if its proof
+// of inclusion verifies against the sparse merkle root hash of the given root record.
+func GetByKey(grpcClient api.MerkleTreeClient, name string, record *RootRecord) (*GetByKeyResponse, error) {
+	opts, sparseRootHash, err := sparseRecord(record)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.GetByKey(ctx, &api.KeyRequest{FileName: name})
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proof, err := toSparseProof(resp.Proof)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	isVerified, err := mt.VerifySparseInclusion(sparseRootHash, name, resp.FileContent, proof, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	return &GetByKeyResponse{
+		Msg:     fmt.Sprintf("file %s downloaded and verified successfully", name),
+		FileIdx: int(resp.FileIndex),
+		File:    resp.FileContent,
+	}, nil
+}
+
+// ProveAbsent asks the server to prove that no file is stored under the given file name and verifies
+// the proof of exclusion against the sparse merkle root hash of the given root record.
+func ProveAbsent(grpcClient api.MerkleTreeClient, name string, record *RootRecord) (*VerifyResponse, error) {
+	opts, sparseRootHash, err := sparseRecord(record)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := grpcClient.ProveAbsent(ctx, &api.KeyRequest{FileName: name})
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proof, err := toSparseProof(resp.Proof)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	isVerified, err := mt.VerifySparseExclusion(sparseRootHash, name, proof, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	return &VerifyResponse{
+		Msg:       fmt.Sprintf("file %s is verifiably absent", name),
+		IsVerfied: true,
+	}, nil
+}
+
+// sparseRecord returns the options and the decoded sparse merkle root hash of the root record.
+func sparseRecord(record *RootRecord) ([]mt.Option, []byte, error) {
+	if record.SparseRootHash == "" {
+		return nil, nil, mterr.ErrNotKeyed
+	}
+
+	opts, err := record.Scheme.Options()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sparseRootHash, err := mt.DecodeHash(record.SparseRootHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return opts, sparseRootHash, nil
+}
+
+type MultiVerifyRequest struct {
+	RootHash  []byte          `json:"root_hash"`
+	LeafCount int             `json:"leaf_count"`
+	FileIdxs  []int           `json:"file_idxs"`
+	Files     [][]byte        `json:"files"`
+	Proofs    []*api.TreeNode `json:"proofs"`
+	mt.Scheme
+}
+
+// VerifyMultiProofLocally verifies the merkle multi-proof of several downloaded files on the client side
+// without contacting the server, trusting only the persisted root hash and leaf count.
+func VerifyMultiProofLocally(req MultiVerifyRequest) (*VerifyResponse, error) {
+	opts, err := req.Scheme.Options()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	rootHash, err := mt.DecodeHash(string(req.RootHash))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs, err := toMerkleNodes(req.Proofs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	isVerified, err := mt.VerifyMultiProof(rootHash, req.FileIdxs, req.Files, req.LeafCount, proofs, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	msg := fmt.Sprintf("merkle verification for files %v is successful", req.FileIdxs)
+	return &VerifyResponse{
+		Msg:       msg,
+		IsVerfied: true,
+	}, nil
+}
+
+// toSparseProof converts a sparse merkle proof received over gRPC.
+func toSparseProof(apiProof *api.SparseProof) (*mt.SparseProof, error) {
+	if apiProof == nil {
+		return nil, mterr.ErrEmptyNode
+	}
+
+	proof := &mt.SparseProof{Siblings: make([][]byte, len(apiProof.Siblings))}
+	for idx, sibling := range apiProof.Siblings {
+		hash, err := mt.DecodeHash(sibling)
+		if err != nil {
+			return nil, err
+		}
+		proof.Siblings[idx] = hash
+	}
+
+	if apiProof.LeafKey != "" {
+		var err error
+		if proof.LeafKey, err = mt.DecodeHash(apiProof.LeafKey); err != nil {
+			return nil, err
+		}
+		if proof.LeafValueHash, err = mt.DecodeHash(apiProof.LeafValueHash); err != nil {
+			return nil, err
+		}
+	}
+	return proof, nil
+}
+
+// toMerkleNodes converts the proof nodes received over gRPC without their children.
+func toMerkleNodes(proofs []*api.TreeNode) ([]*mt.TreeNode, error) {
+	nodes := make([]*mt.TreeNode, len(proofs))
+	for idx, proof := range proofs {
+		if proof == nil {
+			return nil, mterr.ErrEmptyNode
+		}
+
+		hash, err := mt.DecodeHash(proof.Hash)
+		if err != nil {
+			return nil, err
+		}
+		nodes[idx] = &mt.TreeNode{
+			Hash:     hash,
+			LeftIdx:  int(proof.LeftIdx),
+			RightIdx: int(proof.RightIdx),
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
+)
+
+// RootRecord is the trusted state the client keeps on its disk after uploading the files.
+// Together with a downloaded file and its merkle proof it is all the client needs to verify
+// the file offline. The scheme records how the tree was built, e.g. the hash algorithm.
+// The sparse root hash is only set if the files were uploaded with their names.
+type RootRecord struct {
+	RootHash       string `json:"merkle_root_hash"`
+	LeafCount      int    `json:"leaf_count"`
+	SparseRootHash string `json:"sparse_root_hash,omitempty"`
+	mt.Scheme
+}
+
+// WriteRootRecord persists the root record as JSON to the given file path.
+func WriteRootRecord(path string, record *RootRecord) error {
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, recordJSON, 0644)
+}
+
+// ReadRootRecord reads the root record from the given file path.
+// Files written by older clients only contain the bare merkle root hash of a SHA-256 tree, in which
+// case the returned record has a zero leaf count and the caller has to supply it.
+func ReadRootRecord(path string) (*RootRecord, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content = bytes.TrimSpace(content)
+	if !bytes.HasPrefix(content, []byte("{")) {
+		return &RootRecord{RootHash: string(content)}, nil
+	}
+
+	var record RootRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
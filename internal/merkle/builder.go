@@ -0,0 +1,160 @@
+package merkle
+
+import (
+	"io"
+	"math/bits"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// Builder computes the root hash of a Merkle tree from files added one at a time. It never holds more
+// than one file, which it streams into the leaf hash, and only keeps the digests of the O(log n)
+// complete subtrees that are still waiting for their right sibling. It does not keep a tree to generate
+// proofs from; a tree over files streamed one at a time is built with `BuildMerkleTreeFromLeaves`.
+type Builder struct {
+	cfg       *config
+	leafCount int           // Number of leaves the tree will have, 0 if unknown
+	added     int           // Number of leaves added so far
+	pending   []pendingNode // Complete subtrees without a right sibling yet, ordered from left to right
+}
+
+// pendingNode is a complete subtree covering the leaves `[l, r]`.
+type pendingNode struct {
+	l, r int
+	hash []byte
+}
+
+// NewBuilder returns a builder for a tree over `leafCount` files. With every shape but `ShapeMidpoint`
+// the number of files may be unknown and passed as 0, since the subtrees of such a tree do not depend
+// on the files that follow them. The split of every other shape depends on the total number of
+// files, which therefore has to be known up front.
+func NewBuilder(leafCount int, opts ...Option) (*Builder, error) {
+	cfg := newConfig(opts)
+	switch {
+	case leafCount < 0:
+		return nil, mterr.ErrInvalidTreeSize
+	case leafCount == 0 && !cfg.powerOfTwoSplit():
+		return nil, mterr.ErrUnsupportedShape
+	}
+	return &Builder{cfg: cfg, leafCount: leafCount}, nil
+}
+
+// ResumeBuilder returns a builder of unknown size that continues the tree over `leafCount` files from the
+// digests of its peaks, the perfect subtrees returned by `MerkleTree.Peaks`. Since the peaks are all the
+// builder keeps of the files added so far, `Finish` returns the root hash of the tree over the `leafCount`
+// files until more files are added. Only the shapes whose number of files may be unknown to `NewBuilder`
+// can be resumed.
+func ResumeBuilder(leafCount int, peaks [][]byte, opts ...Option) (*Builder, error) {
+	cfg := newConfig(opts)
+	switch {
+	case !cfg.powerOfTwoSplit():
+		return nil, mterr.ErrUnsupportedShape
+	case leafCount <= 0:
+		return nil, mterr.ErrInvalidTreeSize
+	case len(peaks) != bits.OnesCount(uint(leafCount)):
+		return nil, mterr.ErrInvalidProof
+	}
+
+	b := &Builder{cfg: cfg, added: leafCount}
+	for _, peak := range (&mountainRange{leafCount: leafCount}).peaks() {
+		hash := peaks[len(b.pending)]
+		if len(hash) != cfg.hasher.Size() {
+			return nil, mterr.ErrInvalidProof
+		}
+		b.pending = append(b.pending, pendingNode{l: peak.l, r: peak.r, hash: append([]byte(nil), hash...)})
+	}
+	return b, nil
+}
+
+// Add reads the next file until EOF and adds it as the next leaf.
+func (b *Builder) Add(r io.Reader) error {
+	if b.leafCount != 0 && b.added == b.leafCount {
+		return mterr.ErrLeafCountMisMatch
+	}
+
+	leaf, err := b.cfg.hashFileFrom(r)
+	if err != nil {
+		return err
+	}
+	b.addLeaf(leaf)
+	return nil
+}
+
+// AddLeaf adds the digest of the next leaf, e.g. of a file hashed in pieces with a `FileHasher`.
+func (b *Builder) AddLeaf(leaf []byte) error {
+	switch {
+	case b.leafCount != 0 && b.added == b.leafCount:
+		return mterr.ErrLeafCountMisMatch
+	case len(leaf) != b.cfg.hasher.Size():
+		return mterr.ErrInvalidHash
+	}
+
+	b.addLeaf(append([]byte(nil), leaf...))
+	return nil
+}
+
+// addLeaf adds the leaf digest and merges all complete subtrees it completes.
+func (b *Builder) addLeaf(leaf []byte) {
+	node := pendingNode{l: b.added, r: b.added, hash: leaf}
+	b.added++
+	for len(b.pending) != 0 && b.isRightChild(node) {
+		left := b.pending[len(b.pending)-1]
+		b.pending = b.pending[:len(b.pending)-1]
+		node = pendingNode{l: left.l, r: node.r, hash: b.cfg.hashChildren(left.l, left.r, node.r, left.hash, node.hash)}
+	}
+	b.pending = append(b.pending, node)
+}
+
+// LeafCount returns the number of files added so far.
+func (b *Builder) LeafCount() int {
+	return b.added
+}
+
+// Finish returns the root hash of the tree over the files added so far. If the number of files was
+// passed to `NewBuilder`, exactly that many files have to be added. Without it more files may be
+// added afterwards, and `Finish` then returns the root hash of the grown tree.
+func (b *Builder) Finish() ([]byte, error) {
+	switch {
+	case b.added == 0:
+		return nil, mterr.ErrEmptyFile
+	case b.leafCount != 0 && b.added != b.leafCount:
+		return nil, mterr.ErrLeafCountMisMatch
+	}
+
+	return b.root(), nil
+}
+
+// root folds the pending subtrees into the root hash. Only trees of unknown size end with
+// several pending subtrees, which are the left children on the right border of the tree.
+func (b *Builder) root() []byte {
+	root, r := b.pending[len(b.pending)-1].hash, b.pending[len(b.pending)-1].r
+	for idx := len(b.pending) - 2; idx >= 0; idx-- {
+		root = b.cfg.hashChildren(b.pending[idx].l, b.pending[idx].r, r, b.pending[idx].hash, root)
+	}
+	return append([]byte(nil), root...)
+}
+
+// isRightChild reports whether the complete subtree is the right child of its parent, whose left
+// child is then the last pending subtree.
+func (b *Builder) isRightChild(node pendingNode) bool {
+	if b.leafCount == 0 {
+		// The left children of RFC 6962 and Bitcoin trees are perfect, so a subtree is merged with its left
+		// sibling as soon as both cover the same number of leaves
+		left := b.pending[len(b.pending)-1]
+		return left.r-left.l == node.r-node.l
+	}
+
+	l, r := 0, b.leafCount-1
+	for l != node.l || r != node.r {
+		mid := b.cfg.split(l, r)
+		if node.r <= mid {
+			r = mid
+		} else {
+			if mid+1 == node.l && r == node.r {
+				return true
+			}
+			l = mid + 1
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+// Content-defined chunks are at least a quarter and at most eight times the chunk size long.
+const (
+	cdcMinRatio = 4
+	cdcMaxRatio = 8
+)
+
+// gear maps every byte to a pseudo-random 64-bit value that is shifted into the rolling hash.
+// It is derived from SHA-256, so the cut points and hence the roots never change.
+var gear = func() (gear [256]uint64) {
+	for idx := range gear {
+		sum := sha256.Sum256([]byte{byte(idx)})
+		gear[idx] = binary.BigEndian.Uint64(sum[:8])
+	}
+	return gear
+}()
+
+// cdcCut returns the length of the first content-defined chunk of `data` following FastCDC. Each
+// byte is shifted into a rolling hash that depends on the last 64 bytes only, and the chunk ends
+// after the first byte at which the masked hash is zero. The first quarter of the chunk size is
+// skipped, and normalized chunking applies a stricter mask before and a looser one after the chunk
+// size, which concentrates the chunk lengths around it. Chunks are cut at eight times the chunk
+// size at the latest.
+func (cfg *config) cdcCut(data []byte) (n int, atCut bool) {
+	minSize, maxSize := cfg.chunkSize/cdcMinRatio, cfg.chunkSize*cdcMaxRatio
+	if len(data) <= minSize {
+		return len(data), false
+	}
+
+	n = min(len(data), maxSize)
+	normal := min(cfg.chunkSize, n)
+	strict, loose := cdcMasks(cfg.chunkSize)
+
+	var hash uint64
+	idx := minSize
+	for ; idx < normal; idx++ {
+		hash = hash<<1 + gear[data[idx]]
+		if hash&strict == 0 {
+			return idx + 1, true
+		}
+	}
+	for ; idx < n; idx++ {
+		hash = hash<<1 + gear[data[idx]]
+		if hash&loose == 0 {
+			return idx + 1, true
+		}
+	}
+	return n, n == maxSize
+}
+
+// cdcMasks returns the masks over the high bits of the rolling hash, which hold the contributions of
+// the most bytes. The strict mask has two bits more and the loose mask two bits less than the binary
+// logarithm of the chunk size.
+func cdcMasks(chunkSize int) (strict, loose uint64) {
+	logSize := bits.Len(uint(chunkSize)) - 1
+	return ^uint64(0) << (64 - min(logSize+2, 64)), ^uint64(0) << (64 - max(logSize-2, 1))
+}
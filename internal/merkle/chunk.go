@@ -0,0 +1,360 @@
+package merkle
+
+import (
+	"bytes"
+	"hash"
+	"io"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// DefaultChunkSize is the chunk size the CLI uploads files with.
+const DefaultChunkSize = 1 << 20
+
+// Chunking selects where files are split into chunks.
+type Chunking int
+
+const (
+	// ChunkingFixed splits files into chunks of exactly the chunk size, only the last chunk may be shorter.
+	ChunkingFixed Chunking = 1
+
+	// ChunkingContentDefined cuts files where a rolling hash over their content matches (FastCDC), aiming at
+	// chunks of the chunk size on average. Inserting or removing bytes only changes the chunks around the
+	// edit, so near-identical files share most of their chunks.
+	ChunkingContentDefined Chunking = 2
+)
+
+// WithChunkSize splits every file into chunks of the given number of bytes. The leaf of a file is then
+// the root of a sub-tree over its chunks, which lets a single chunk be verified against the root hash
+// without downloading the whole file. Sub-trees always use the `ShapeRFC6962` shape, so they can be
+// built while a file is read. A file of at most one chunk keeps the leaf digest it has without chunking.
+// Files are not chunked by default.
+func WithChunkSize(chunkSize int) Option {
+	return func(cfg *config) {
+		if chunkSize > 0 {
+			cfg.chunkSize = chunkSize
+		}
+	}
+}
+
+// WithChunking selects where files are split into chunks of the size passed to `WithChunkSize`.
+// Files are split with `ChunkingFixed` by default.
+func WithChunking(chunking Chunking) Option {
+	return func(cfg *config) {
+		if chunking != 0 {
+			cfg.chunking = chunking
+		}
+	}
+}
+
+// Chunks splits the file into the chunks the sub-tree of its leaf is built over. Empty files consist
+// of a single empty chunk. The chunks share the memory of the file.
+func Chunks(file []byte, opts ...Option) [][]byte {
+	return newConfig(opts).chunks(file)
+}
+
+// ChunkKey returns the content address of a chunk, the hexadecimal SHA-256 digest of its bytes. It does
+// not depend on the scheme, so equal chunks of trees built with different schemes share the same key.
+func ChunkKey(chunk []byte) string {
+	return EncodeHash(chunkKeyHasher.Sum(chunk))
+}
+
+// chunkKeyHasher calculates the content addresses of chunks.
+var chunkKeyHasher = DefaultHasher()
+
+// GenerateChunkProof generates the proof path of the chunk at `chunkIdx` in the sub-tree of the file,
+// ordered from the chunk up to the root of the sub-tree. Together with the proof of the file it links
+// the chunk to the root hash of the Merkle tree. The file is split and every chunk is hashed on each
+// call; callers serving several chunks of a file keep the sub-tree of `BuildChunkTree` instead.
+func GenerateChunkProof(file []byte, chunkIdx int, opts ...Option) ([]*TreeNode, error) {
+	chunks := Chunks(file, opts...)
+	if chunkIdx < 0 || chunkIdx >= len(chunks) {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	leaves := make([][]byte, len(chunks))
+	for idx, chunk := range chunks {
+		leaves[idx] = ChunkLeafHash(chunk, opts...)
+	}
+
+	subTree, err := BuildChunkTree(leaves, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return subTree.GenerateMerkleProof(chunkIdx)
+}
+
+// ChunkLeafHash calculates the digest of a chunk as it is stored in the leaf of the sub-tree of its file.
+func ChunkLeafHash(chunk []byte, opts ...Option) []byte {
+	return newConfig(opts).chunkConfig().hashLeaf(chunk)
+}
+
+// BuildChunkTree builds the sub-tree of a file over the digests of its chunks, each calculated with
+// `ChunkLeafHash` and the options of the Merkle tree. Its root hash is the leaf of the file, and the proof
+// of a chunk is generated with `GenerateMerkleProof` of the sub-tree in `O(log n)`, which lets a server
+// keep the sub-tree of a file rather than splitting and hashing the whole file for every chunk it serves.
+func BuildChunkTree(chunkLeaves [][]byte, opts ...Option) (*MerkleTree, error) {
+	chunkCfg := newConfig(opts).chunkConfig()
+	if len(chunkLeaves) == 0 {
+		return nil, mterr.ErrEmptyFile
+	}
+	for _, leaf := range chunkLeaves {
+		if len(leaf) != chunkCfg.hasher.Size() {
+			return nil, mterr.ErrInvalidHash
+		}
+	}
+
+	subTree := &MerkleTree{cfg: chunkCfg}
+	subTree.build(chunkLeaves)
+	return subTree, nil
+}
+
+// VerifyChunkProof verifies that `chunk` is the chunk at `chunkIdx` of a file of `chunkCount` chunks which
+// is stored at `leafIdx` in a tree built over `leafCount` files whose root hash is `rootHash`. The chunk is
+// first folded with `chunkProofs` into the leaf of the file, which is then folded with `fileProofs` into the
+// root. Every chunk but the last one has to end exactly where the chunking cuts the file, which binds the
+// chunk to its byte offset in the file.
+func VerifyChunkProof(rootHash, chunk []byte, chunkIdx, chunkCount int, chunkProofs []*TreeNode, leafIdx, leafCount int, fileProofs []*TreeNode, opts ...Option) (bool, error) {
+	switch {
+	case leafCount <= 0:
+		return false, mterr.ErrEmptyRoot
+	case leafIdx < 0 || leafIdx >= leafCount || chunkIdx < 0 || chunkIdx >= chunkCount:
+		return false, mterr.ErrIndexOutOfBound
+	}
+
+	cfg := newConfig(opts)
+	if chunkCount > 1 && !cfg.isChunk(chunk, chunkIdx == chunkCount-1) {
+		return false, nil
+	}
+
+	chunkCfg := cfg.chunkConfig()
+	leafHash, err := chunkCfg.rootHash(chunkCfg.hashLeaf(chunk), chunkIdx, chunkCount, chunkProofs)
+	if err != nil {
+		return false, err
+	}
+
+	merkleHash, err := cfg.rootHash(leafHash, leafIdx, leafCount, fileProofs)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(merkleHash, rootHash), nil
+}
+
+// hashFile calculates the digest of the leaf of a file, which is the root of the sub-tree over its chunks.
+func (cfg *config) hashFile(file []byte) []byte {
+	chunks := cfg.chunks(file)
+	if len(chunks) == 1 {
+		return cfg.hashLeaf(file)
+	}
+
+	b := &Builder{cfg: cfg.chunkConfig()}
+	for _, chunk := range chunks {
+		b.addLeaf(b.cfg.hashLeaf(chunk))
+	}
+	return b.root()
+}
+
+// hashFileFrom calculates the digest of the leaf of a file read until EOF. Only a single chunk is held in memory.
+func (cfg *config) hashFileFrom(r io.Reader) ([]byte, error) {
+	if cfg.chunkSize == 0 {
+		return cfg.hashLeafFrom(r)
+	}
+
+	b := &Builder{cfg: cfg.chunkConfig()}
+	cr := newChunkReader(cfg, r)
+	for {
+		chunk, err := cr.next()
+		if err == io.EOF {
+			return b.root(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.addLeaf(b.cfg.hashLeaf(chunk))
+	}
+}
+
+// FileHasher computes the leaf digest of a file written to it in pieces of any size, so that a file read or
+// received in pieces is never held in memory as a whole. It splits the file into its chunks on the way and
+// only buffers the chunk in progress. The whole file is only buffered if it is not chunked and its single chunk
+// is collected, or if the hasher does not provide a `hash.Hash` to stream the file into.
+type FileHasher struct {
+	cfg       *config
+	onChunk   func(chunk []byte) // Called with every complete chunk, may be nil
+	chunkTree *Builder           // Sub-tree over the chunks, nil without chunk size
+	buf       []byte             // Bytes written after the last complete chunk
+	leaf      hash.Hash          // Leaf digest of a file without chunk size, nil if the file is buffered
+	value     hash.Hash          // Digest of the file in a sparse Merkle tree, nil if the file is buffered
+	file      []byte             // Whole file, only buffered if one of the digests cannot be streamed
+	keepFile  bool
+}
+
+// NewFileHasher returns a hasher for a single file. Every complete chunk of the file is passed to `onChunk`,
+// which must not keep it beyond the call unless it copies it. `onChunk` may be nil.
+func NewFileHasher(onChunk func(chunk []byte), opts ...Option) *FileHasher {
+	cfg := newConfig(opts)
+	fh := &FileHasher{cfg: cfg, onChunk: onChunk}
+	if streamer, ok := cfg.hasher.(interface{ New() hash.Hash }); ok {
+		fh.value = streamer.New()
+		if cfg.chunkSize == 0 {
+			fh.leaf = streamer.New()
+			if cfg.domainSeparation {
+				fh.leaf.Write([]byte{leafPrefix})
+			}
+		}
+	}
+	if cfg.chunkSize != 0 {
+		fh.chunkTree = &Builder{cfg: cfg.chunkConfig()}
+	}
+	fh.keepFile = fh.value == nil || (cfg.chunkSize == 0 && onChunk != nil)
+	return fh
+}
+
+// Write adds the next piece of the file. Every chunk completed by the piece is hashed and passed on right away.
+func (fh *FileHasher) Write(p []byte) (int, error) {
+	if fh.value != nil {
+		fh.value.Write(p)
+	}
+	if fh.leaf != nil {
+		fh.leaf.Write(p)
+	}
+	if fh.keepFile {
+		fh.file = append(fh.file, p...)
+	}
+	if fh.chunkTree != nil {
+		fh.buf = append(fh.buf, p...)
+		for len(fh.buf) >= fh.cfg.maxChunkSize() {
+			fh.cutChunk()
+		}
+	}
+	return len(p), nil
+}
+
+// Finish completes the file and returns the digest of its leaf together with the digest of its content as
+// the value of a sparse Merkle tree (`SparseMerkleTree.PutValueHash`). The hasher must not be used afterwards.
+func (fh *FileHasher) Finish() (leaf, valueHash []byte) {
+	if fh.chunkTree != nil {
+		// Empty files consist of a single empty chunk
+		for len(fh.buf) != 0 || fh.chunkTree.added == 0 {
+			fh.cutChunk()
+		}
+		leaf = fh.chunkTree.root()
+	} else {
+		if fh.onChunk != nil {
+			fh.onChunk(fh.file)
+		}
+		if fh.leaf != nil {
+			leaf = fh.leaf.Sum(nil)
+		} else {
+			leaf = fh.cfg.hashLeaf(fh.file)
+		}
+	}
+
+	if fh.value != nil {
+		valueHash = fh.value.Sum(nil)
+	} else {
+		valueHash = fh.cfg.hasher.Sum(fh.file)
+	}
+	return leaf, valueHash
+}
+
+// cutChunk hashes the first chunk of the buffered bytes into the sub-tree of the file and passes it on.
+func (fh *FileHasher) cutChunk() {
+	n, _ := fh.cfg.cut(fh.buf)
+	chunk := fh.buf[:n:n]
+	fh.chunkTree.addLeaf(fh.chunkTree.cfg.hashLeaf(chunk))
+	if fh.onChunk != nil {
+		fh.onChunk(chunk)
+	}
+	fh.buf = fh.buf[n:]
+}
+
+// chunks splits the file into its chunks.
+func (cfg *config) chunks(file []byte) [][]byte {
+	if cfg.chunkSize == 0 {
+		return [][]byte{file}
+	}
+
+	var chunks [][]byte
+	for len(file) != 0 || chunks == nil {
+		n, _ := cfg.cut(file)
+		chunks = append(chunks, file[:n:n])
+		file = file[n:]
+	}
+	return chunks
+}
+
+// cut returns the length of the first chunk of `data`. `atCut` reports whether the chunk ends where the
+// chunking cuts, rather than only because `data` ends.
+func (cfg *config) cut(data []byte) (n int, atCut bool) {
+	if cfg.chunking == ChunkingContentDefined {
+		return cfg.cdcCut(data)
+	}
+	n = min(len(data), cfg.chunkSize)
+	return n, n == cfg.chunkSize
+}
+
+// isChunk reports whether `chunk` can be a complete chunk of a file, which every chunk but the `last` one
+// of a file has to end with a cut.
+func (cfg *config) isChunk(chunk []byte, last bool) bool {
+	n, atCut := cfg.cut(chunk)
+	return len(chunk) != 0 && n == len(chunk) && (atCut || last)
+}
+
+// maxChunkSize returns the length of the longest chunk the chunking cuts.
+func (cfg *config) maxChunkSize() int {
+	if cfg.chunking == ChunkingContentDefined {
+		return cfg.chunkSize * cdcMaxRatio
+	}
+	return cfg.chunkSize
+}
+
+// chunkConfig returns the settings the sub-trees over the chunks of a file are built with.
+func (cfg *config) chunkConfig() *config {
+	chunkCfg := *cfg
+	chunkCfg.shape = ShapeRFC6962
+	chunkCfg.layout = LayoutPointer
+	chunkCfg.chunkSize = 0
+	return &chunkCfg
+}
+
+// chunkReader splits a stream into its chunks while holding at most one chunk of the maximal length in memory.
+type chunkReader struct {
+	cfg    *config
+	r      io.Reader
+	buf    []byte
+	n      int  // Number of bytes read into `buf`
+	cut    int  // Length of the chunk returned last, which is dropped from `buf` on the next call
+	eof    bool // Whether `r` is exhausted
+	chunks int  // Number of chunks returned so far
+}
+
+// newChunkReader returns a chunk reader for the configured chunking.
+func newChunkReader(cfg *config, r io.Reader) *chunkReader {
+	return &chunkReader{cfg: cfg, r: r, buf: make([]byte, cfg.maxChunkSize())}
+}
+
+// next returns the next chunk, or `io.EOF` after the last one. The chunk is only valid until the next call.
+func (cr *chunkReader) next() ([]byte, error) {
+	cr.n = copy(cr.buf, cr.buf[cr.cut:cr.n])
+	if !cr.eof {
+		m, err := io.ReadFull(cr.r, cr.buf[cr.n:])
+		cr.n += m
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			cr.eof = true
+		default:
+			return nil, err
+		}
+	}
+
+	// Empty files consist of a single empty chunk
+	if cr.n == 0 && cr.chunks != 0 {
+		return nil, io.EOF
+	}
+
+	cr.cut, _ = cr.cfg.cut(cr.buf[:cr.n])
+	cr.chunks++
+	return cr.buf[:cr.cut], nil
+}
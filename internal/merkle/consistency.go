@@ -0,0 +1,131 @@
+package merkle
+
+import (
+	"bytes"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// GenerateConsistencyProof generates a proof that the first `oldSize` leaves of the tree built over the
+// first `newSize` leaves are exactly the leaves of the tree of size `oldSize`, i.e. that the tree only
+// grew by appending (RFC 9162, section 2.1.4). The proof nodes are ordered as the RFC prescribes.
+// Only trees with the `ShapeRFC6962` or the `ShapeMountainRange` shape keep their old subtrees when
+// growing, so other shapes are refused.
+func (mt *MerkleTree) GenerateConsistencyProof(oldSize, newSize int) ([]*TreeNode, error) {
+	switch {
+	case !mt.cfg.appendOnly():
+		return nil, mterr.ErrUnsupportedShape
+	case oldSize <= 0 || oldSize > newSize || newSize > mt.LeafCount():
+		return nil, mterr.ErrInvalidTreeSize
+	}
+	return mt.subProof(oldSize, 0, newSize-1, true), nil
+}
+
+// subProof implements SUBPROOF(m, D[l:r+1], b) of RFC 9162, section 2.1.4.1.
+func (mt *MerkleTree) subProof(m, l, r int, complete bool) []*TreeNode {
+	if m == r-l+1 {
+		if complete {
+			return nil
+		}
+		return []*TreeNode{mt.rangeNode(l, r)}
+	}
+
+	mid := mt.cfg.split(l, r)
+	if k := mid - l + 1; m <= k {
+		return append(mt.subProof(m, l, mid, complete), mt.rangeNode(mid+1, r))
+	}
+	return append(mt.subProof(m-(mid-l+1), mid+1, r, false), mt.rangeNode(l, mid))
+}
+
+// rangeNode returns the node covering the leaves `[l, r]` in the tree built over the first `r+1` leaves.
+// Nodes on the right border of a smaller tree do not exist in the current tree and are recomputed from
+// the nodes below them.
+func (mt *MerkleTree) rangeNode(l, r int) *TreeNode {
+	return &TreeNode{Hash: mt.rangeHash(l, r), LeftIdx: l, RightIdx: r}
+}
+
+// rangeHash returns the digest of the node covering the leaves `[l, r]` in the tree built over the first `r+1` leaves.
+func (mt *MerkleTree) rangeHash(l, r int) []byte {
+	curr := mt.rootRef()
+	for curr.l != l || curr.r != r {
+		left, right := mt.children(curr)
+		switch {
+		case r <= left.r:
+			curr = left
+		case l >= right.l:
+			curr = right
+		default:
+			mid := mt.cfg.split(l, r)
+			return mt.cfg.hashNode(mt.rangeHash(l, mid), mt.rangeHash(mid+1, r))
+		}
+	}
+	return append([]byte(nil), mt.digest(curr)...)
+}
+
+// VerifyConsistencyProof verifies a consistency proof without access to the Merkle tree following
+// RFC 9162, section 2.1.4.2. It checks that the tree of size `newSize` with root hash `newRootHash`
+// extends the tree of size `oldSize` with root hash `oldRootHash` by appended leaves only. The options
+// must match the ones the tree was built with and select the `ShapeRFC6962` or the `ShapeMountainRange` shape.
+func VerifyConsistencyProof(oldRootHash, newRootHash []byte, oldSize, newSize int, proofs []*TreeNode, opts ...Option) (bool, error) {
+	cfg := newConfig(opts)
+	switch {
+	case !cfg.appendOnly():
+		return false, mterr.ErrUnsupportedShape
+	case oldSize <= 0 || oldSize > newSize:
+		return false, mterr.ErrInvalidTreeSize
+	}
+
+	path := make([][]byte, 0, len(proofs)+1)
+	for _, proof := range proofs {
+		if proof == nil {
+			return false, mterr.ErrEmptyNode
+		}
+		path = append(path, proof.Hash)
+	}
+
+	if oldSize == newSize {
+		if len(path) != 0 {
+			return false, mterr.ErrInvalidProof
+		}
+		return bytes.Equal(oldRootHash, newRootHash), nil
+	}
+
+	// The old root is a node of the new tree if the old size is a power of two and is left out of the proof
+	if oldSize&(oldSize-1) == 0 {
+		path = append([][]byte{oldRootHash}, path...)
+	}
+	if len(path) == 0 {
+		return false, mterr.ErrInvalidProof
+	}
+
+	fn, sn := oldSize-1, newSize-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+
+	fr, sr := path[0], path[0]
+	for _, c := range path[1:] {
+		if sn == 0 {
+			return false, mterr.ErrInvalidProof
+		}
+
+		if fn&1 == 1 || fn == sn {
+			fr = cfg.hashNode(c, fr)
+			sr = cfg.hashNode(c, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = cfg.hashNode(sr, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 {
+		return false, mterr.ErrInvalidProof
+	}
+	return bytes.Equal(fr, oldRootHash) && bytes.Equal(sr, newRootHash), nil
+}
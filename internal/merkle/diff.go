@@ -0,0 +1,200 @@
+package merkle
+
+import (
+	"bytes"
+	"sort"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// Diff returns the indices of the leaves that differ between both trees in ascending order. Both trees
+// have to be built with the same scheme over the same number of leaves, so that their nodes cover the
+// same leaves. Both trees are walked together and subtrees with equal digests are skipped, hence only
+// O(k log n) nodes are compared for k differing leaves.
+func Diff(a, b *MerkleTree) ([]int, error) {
+	if a.GetMerkleRoot() == nil || b.GetMerkleRoot() == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+	if !a.Scheme().Equal(b.Scheme()) {
+		return nil, mterr.ErrSchemeMisMatch
+	}
+	if a.LeafCount() != b.LeafCount() {
+		return nil, mterr.ErrLeafCountMisMatch
+	}
+
+	var diff []int
+	var walk func(x, y nodeRef)
+	walk = func(x, y nodeRef) {
+		switch {
+		case bytes.Equal(a.digest(x), b.digest(y)):
+		case x.l == x.r:
+			diff = append(diff, x.l)
+		default:
+			xLeft, xRight := a.children(x)
+			yLeft, yRight := b.children(y)
+			walk(xLeft, yLeft)
+			walk(xRight, yRight)
+		}
+	}
+	walk(a.rootRef(), b.rootRef())
+	return diff, nil
+}
+
+// DiffLevels compares the tree with a remote tree built with the same scheme over the same number of
+// leaves, which is only accessible through `getNodes`, and returns the indices of the differing leaves
+// in ascending order. Like `GetNodes`, `getNodes` has to return the nodes `[from, to]` of a level of the
+// remote tree. The trees are compared level by level from the root, and only the children of differing
+// nodes are requested, one contiguous run of positions at a time. The number of requested nodes is
+// therefore proportional to the number of differing leaves.
+func DiffLevels(mt *MerkleTree, getNodes func(level, from, to int) ([]*TreeNode, error)) ([]int, error) {
+	if mt.GetMerkleRoot() == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	widths := newLevelWidths(mt.cfg)
+	leafCount := mt.LeafCount()
+	var diff []int
+	frontier := []nodeRef{mt.rootRef()}
+	for level := 0; len(frontier) > 0; level++ {
+		remote, err := widths.getLevel(getNodes, level, leafCount, frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []nodeRef
+		for i, n := range frontier {
+			switch {
+			case bytes.Equal(mt.digest(n), remote[i].Hash):
+			case n.l == n.r:
+				diff = append(diff, n.l)
+			default:
+				left, right := mt.children(n)
+				next = append(next, left, right)
+			}
+		}
+		frontier = next
+	}
+
+	sort.Ints(diff)
+	return diff, nil
+}
+
+// GetNodes returns the nodes on positions `from` to `to` of the given level of the tree, the root being
+// on level 0. The nodes of a level are numbered from left to right starting at 0. Leaves closer to the root
+// than the level have no nodes on it. The returned nodes are detached from the tree and carry no children.
+func (mt *MerkleTree) GetNodes(level, from, to int) ([]*TreeNode, error) {
+	if mt.GetMerkleRoot() == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	widths := newLevelWidths(mt.cfg)
+	if level < 0 || from < 0 || from > to || to >= widths.width(mt.LeafCount(), level) {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	nodes := make([]*TreeNode, 0, to-from+1)
+	var walk func(n nodeRef, offset int)
+	walk = func(n nodeRef, offset int) {
+		if n.depth == level {
+			nodes = append(nodes, &TreeNode{Hash: append([]byte(nil), mt.digest(n)...), LeftIdx: n.l, RightIdx: n.r})
+			return
+		}
+
+		// Only subtrees reaching down to the level and overlapping the requested positions are visited
+		left, right := mt.children(n)
+		leftWidth := widths.width(left.r-left.l+1, level-left.depth)
+		rightWidth := widths.width(right.r-right.l+1, level-right.depth)
+		if leftWidth > 0 && from < offset+leftWidth && to >= offset {
+			walk(left, offset)
+		}
+		if rightWidth > 0 && from < offset+leftWidth+rightWidth && to >= offset+leftWidth {
+			walk(right, offset+leftWidth)
+		}
+	}
+	walk(mt.rootRef(), 0)
+	return nodes, nil
+}
+
+// levelWidths counts the nodes on the levels of subtrees. Since the split of every shape only depends on
+// the number of leaves of a node, subtrees of equal size have the same number of nodes on each level.
+type levelWidths struct {
+	cfg  *config
+	memo map[[2]int]int
+}
+
+func newLevelWidths(cfg *config) *levelWidths {
+	return &levelWidths{cfg: cfg, memo: make(map[[2]int]int)}
+}
+
+// width returns the number of nodes `depth` levels below the root of a subtree over `size` leaves.
+func (lw *levelWidths) width(size, depth int) int {
+	switch {
+	case depth == 0:
+		return 1
+	case size == 1:
+		return 0
+	}
+
+	key := [2]int{size, depth}
+	if width, ok := lw.memo[key]; ok {
+		return width
+	}
+	mid := lw.cfg.split(0, size-1)
+	width := lw.width(mid+1, depth-1) + lw.width(size-mid-1, depth-1)
+	lw.memo[key] = width
+	return width
+}
+
+// getLevel requests the nodes covering the leaves of the given nodes on a level of a remote tree over `leafCount`
+// leaves through `getNodes`. Nodes on consecutive positions are requested at once. The returned nodes have to
+// cover the same leaves as the given ones, otherwise the remote tree has another size or shape.
+func (lw *levelWidths) getLevel(getNodes func(level, from, to int) ([]*TreeNode, error), level, leafCount int, refs []nodeRef) ([]*TreeNode, error) {
+	nodes := make([]*TreeNode, 0, len(refs))
+	for start := 0; start < len(refs); {
+		from := lw.position(refs[start].l, refs[start].r, level, leafCount)
+		end := start + 1
+		for end < len(refs) && lw.position(refs[end].l, refs[end].r, level, leafCount) == from+end-start {
+			end++
+		}
+
+		remote, err := getNodes(level, from, from+end-start-1)
+		if err != nil {
+			return nil, err
+		}
+		if len(remote) != end-start {
+			return nil, mterr.ErrIndexOutOfBound
+		}
+
+		for i, n := range refs[start:end] {
+			switch {
+			case remote[i] == nil:
+				return nil, mterr.ErrEmptyNode
+			case level == 0 && (remote[i].LeftIdx != n.l || remote[i].RightIdx != n.r):
+				return nil, mterr.ErrLeafCountMisMatch
+			case remote[i].LeftIdx != n.l || remote[i].RightIdx != n.r:
+				return nil, mterr.ErrSchemeMisMatch
+			}
+		}
+		nodes = append(nodes, remote...)
+		start = end
+	}
+	return nodes, nil
+}
+
+// position returns the position of the node covering the leaves `[l, r]` on the given level of a tree over
+// `leafCount` leaves. The tree is descended from the root by the split alone, counting the nodes on that
+// level in the left subtrees passed on the way, so the nodes of the tree need not be at hand.
+func (lw *levelWidths) position(l, r, level, leafCount int) int {
+	pos := 0
+	currL, currR := 0, leafCount-1
+	for depth := 0; depth < level; depth++ {
+		mid := lw.cfg.split(currL, currR)
+		if l <= mid {
+			currR = mid
+		} else {
+			pos += lw.width(mid-currL+1, level-depth-1)
+			currL = mid + 1
+		}
+	}
+	return pos
+}
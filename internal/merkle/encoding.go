@@ -0,0 +1,305 @@
+package merkle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// encodingMagic starts every encoded Merkle tree.
+var encodingMagic = [4]byte{'M', 'G', 'M', 'T'}
+
+// encodingVersion is the version of the format `WriteTo` writes. Readers refuse newer versions.
+const encodingVersion uint16 = 1
+
+// crc32c is the table of the CRC-32C checksum that ends every encoded Merkle tree.
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// The encoding of a Merkle tree consists of the following fields, all integers in big endian:
+//
+//	magic              4 bytes  "MGMT"
+//	version            uint16   encoding version, currently 1
+//	hash algorithm     uint8 length followed by the name of the hash algorithm
+//	domain separation  uint8    0 or 1
+//	node version       uint8    `Version`
+//	shape              uint8    `Shape`
+//	layout             uint8    `Layout`
+//	chunking           uint8    `Chunking`
+//	chunk size         uint64
+//	digest size        uint16
+//	leaf count         uint64
+//	digests            the 2n-1 digests of all nodes in pre-order (node, left subtree, right subtree)
+//	checksum           uint32   CRC-32C of all preceding bytes
+//
+// Storing the interior digests lets a tree be loaded without hashing a single node. The shape and
+// the leaf count determine which leaves every node covers.
+
+// MarshalBinary encodes the Merkle tree like `WriteTo`. It implements `encoding.BinaryMarshaler`.
+func (mt *MerkleTree) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := mt.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the Merkle tree by the encoded one like `ReadFrom`. Trailing data is refused.
+// It implements `encoding.BinaryUnmarshaler`.
+func (mt *MerkleTree) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if _, err := mt.ReadFrom(r); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", mterr.ErrInvalidTreeEncoding, r.Len())
+	}
+	return nil
+}
+
+// ReadMerkleTree reads a Merkle tree written by `WriteTo`.
+func ReadMerkleTree(r io.Reader) (*MerkleTree, error) {
+	mt := &MerkleTree{}
+	if _, err := mt.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return mt, nil
+}
+
+// WriteTo writes the Merkle tree to w in the versioned and checksummed binary format, streaming the
+// digests of the nodes one at a time. It implements `io.WriterTo`.
+func (mt *MerkleTree) WriteTo(w io.Writer) (int64, error) {
+	if mt.root == nil && mt.flat == nil && mt.mmr == nil {
+		return 0, mterr.ErrEmptyRoot
+	}
+
+	name := mt.cfg.hasher.Name()
+	if len(name) > math.MaxUint8 {
+		return 0, mterr.ErrUnknownHashAlgorithm
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	checksum := crc32.New(crc32c)
+	enc := io.MultiWriter(bw, checksum)
+
+	header := append([]byte(nil), encodingMagic[:]...)
+	header = binary.BigEndian.AppendUint16(header, encodingVersion)
+	header = append(header, byte(len(name)))
+	header = append(header, name...)
+	header = append(header, boolByte(mt.cfg.domainSeparation), byte(mt.cfg.version), byte(mt.cfg.shape), byte(mt.cfg.layout), byte(mt.cfg.chunking))
+	header = binary.BigEndian.AppendUint64(header, uint64(mt.cfg.chunkSize))
+	header = binary.BigEndian.AppendUint16(header, uint16(mt.cfg.hasher.Size()))
+	header = binary.BigEndian.AppendUint64(header, uint64(mt.LeafCount()))
+	if _, err := enc.Write(header); err != nil {
+		return cw.n, err
+	}
+
+	if err := mt.writeNode(enc, mt.rootRef()); err != nil {
+		return cw.n, err
+	}
+
+	if _, err := bw.Write(binary.BigEndian.AppendUint32(nil, checksum.Sum32())); err != nil {
+		return cw.n, err
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// writeNode writes the digests of the subtree rooted at the node in pre-order.
+func (mt *MerkleTree) writeNode(w io.Writer, n nodeRef) error {
+	if _, err := w.Write(mt.digest(n)); err != nil {
+		return err
+	}
+	if n.l == n.r {
+		return nil
+	}
+
+	left, right := mt.children(n)
+	if err := mt.writeNode(w, left); err != nil {
+		return err
+	}
+	return mt.writeNode(w, right)
+}
+
+// ReadFrom replaces the Merkle tree by the one read from r, which has to be written by `WriteTo`.
+// The tree is restored in the layout it was written from. It implements `io.ReaderFrom`.
+func (mt *MerkleTree) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	checksum := crc32.New(crc32c)
+	dec := &decoder{r: io.TeeReader(cr, checksum)}
+
+	magic := dec.bytes(len(encodingMagic))
+	if dec.err == nil && !bytes.Equal(magic, encodingMagic[:]) {
+		return cr.n, mterr.ErrInvalidTreeEncoding
+	}
+	if version := dec.uint16(); dec.err == nil && version != encodingVersion {
+		return cr.n, fmt.Errorf("%w: %d", mterr.ErrUnsupportedEncoding, version)
+	}
+
+	name := string(dec.bytes(int(dec.uint8())))
+	domainSeparation := dec.uint8()
+	version, shape, layout, chunking := Version(dec.uint8()), Shape(dec.uint8()), Layout(dec.uint8()), Chunking(dec.uint8())
+	chunkSize, digestSize, leafCount := dec.uint64(), int(dec.uint16()), dec.uint64()
+	if dec.err != nil {
+		return cr.n, dec.wrapErr()
+	}
+
+	if domainSeparation > 1 || layout > LayoutFlat || chunkSize > math.MaxInt32 || leafCount == 0 || leafCount > math.MaxInt32 {
+		return cr.n, mterr.ErrInvalidTreeEncoding
+	}
+	opts, err := Scheme{
+		HashAlgorithm:    name,
+		DomainSeparation: domainSeparation == 1,
+		Version:          version,
+		Shape:            shape,
+		ChunkSize:        int(chunkSize),
+		Chunking:         chunking,
+	}.Options()
+	if err != nil {
+		return cr.n, err
+	}
+
+	cfg := newConfig(append(opts, WithLayout(layout)))
+	if digestSize != cfg.hasher.Size() || cfg.layout == LayoutFlat && cfg.shape != ShapeMidpoint {
+		return cr.n, mterr.ErrInvalidTreeEncoding
+	}
+
+	var root *TreeNode
+	var flat *flatTree
+	var mmr *mountainRange
+	// The flat and the mountain range storage is allocated for the leaf count up front, so their digests
+	// are read first. Otherwise a header claiming more leaves than the input holds would allocate the
+	// storage for all of them before the missing digests are noticed.
+	n := int(leafCount)
+	switch {
+	case cfg.shape == ShapeMountainRange:
+		if digests := dec.prefetch((2*n - 1) * digestSize); dec.err == nil {
+			mmr = newMountainRange(cfg, n)
+			mmr.read(cfg, digests, nodeRef{l: 0, r: n - 1})
+		}
+	case cfg.layout == LayoutFlat:
+		if digests := dec.prefetch((2*n - 1) * digestSize); dec.err == nil {
+			flat = newFlatTree(cfg, n)
+			flat.read(digests, nodeRef{l: 0, r: n - 1})
+		}
+	default:
+		root = readNode(cfg, dec, digestSize, 0, n-1)
+	}
+
+	sum := checksum.Sum32()
+	stored := binary.BigEndian.Uint32(dec.bytes(4))
+	if dec.err != nil {
+		return cr.n, dec.wrapErr()
+	}
+	if stored != sum {
+		return cr.n, mterr.ErrChecksumMisMatch
+	}
+
+	mt.root, mt.flat, mt.mmr, mt.cfg = root, flat, mmr, cfg
+	return cr.n, nil
+}
+
+// readNode reads the digests of the subtree covering the leaves `[l, r]` in pre-order.
+func readNode(cfg *config, dec *decoder, size, l, r int) *TreeNode {
+	node := &TreeNode{Hash: dec.bytes(size), LeftIdx: l, RightIdx: r}
+	if l == r || dec.err != nil {
+		return node
+	}
+
+	mid := cfg.split(l, r)
+	node.Left = readNode(cfg, dec, size, l, mid)
+	node.Right = readNode(cfg, dec, size, mid+1, r)
+	return node
+}
+
+// read reads the digests of the subtree rooted at the node in pre-order into the levels.
+func (ft *flatTree) read(dec *decoder, n nodeRef) {
+	dec.read(ft.digest(n))
+	if n.l == n.r || dec.err != nil {
+		return
+	}
+
+	left, right := ft.children(n)
+	ft.read(dec, left)
+	ft.read(dec, right)
+}
+
+// decoder reads big endian fields and keeps the first error, after which all reads return zero values.
+type decoder struct {
+	r   io.Reader
+	err error
+}
+
+// read fills p.
+func (d *decoder) read(p []byte) {
+	if d.err == nil {
+		_, d.err = io.ReadFull(d.r, p)
+	}
+}
+
+// bytes reads the next n bytes.
+func (d *decoder) bytes(n int) []byte {
+	p := make([]byte, n)
+	d.read(p)
+	return p
+}
+
+// prefetch reads the next n bytes and returns a decoder over them. The bytes are buffered as they arrive,
+// so the buffer never outgrows the input even if n does.
+func (d *decoder) prefetch(n int) *decoder {
+	var buf bytes.Buffer
+	if d.err == nil {
+		_, d.err = io.CopyN(&buf, d.r, int64(n))
+	}
+	return &decoder{r: &buf}
+}
+
+func (d *decoder) uint8() uint8   { return d.bytes(1)[0] }
+func (d *decoder) uint16() uint16 { return binary.BigEndian.Uint16(d.bytes(2)) }
+func (d *decoder) uint64() uint64 { return binary.BigEndian.Uint64(d.bytes(8)) }
+
+// wrapErr reports a truncated encoding as invalid.
+func (d *decoder) wrapErr() error {
+	if d.err == io.EOF || d.err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: %v", mterr.ErrInvalidTreeEncoding, io.ErrUnexpectedEOF)
+	}
+	return d.err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// boolByte encodes a bool as 0 or 1.
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,322 @@
+package merkle
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// ExportFormat selects the format a Merkle tree is exported to.
+type ExportFormat string
+
+const (
+	// FormatDOT renders the tree as Graphviz DOT graph, e.g. to an SVG with `dot -Tsvg`.
+	FormatDOT ExportFormat = "dot"
+
+	// FormatJSON renders the tree as nested JSON objects carrying the full hexadecimal digests.
+	FormatJSON ExportFormat = "json"
+
+	// FormatMermaid renders the tree as Mermaid flowchart, which Markdown viewers like GitHub render inline.
+	FormatMermaid ExportFormat = "mermaid"
+)
+
+// ExportFormats returns the names of the supported export formats.
+func ExportFormats() []string {
+	return []string{string(FormatDOT), string(FormatJSON), string(FormatMermaid)}
+}
+
+// Roles of the nodes on a highlighted proof path.
+const (
+	proofPath    = "path"    // Node on the path from the root to the proven leaf
+	proofSibling = "sibling" // Sibling of a node on the path, i.e. a node of the proof
+)
+
+// ExportOption configures which part of a Merkle tree is exported.
+type ExportOption func(*exportConfig)
+
+type exportConfig struct {
+	maxDepth  int // Deepest exported level, negative for all levels
+	proofLeaf int // Index of the leaf whose proof path is highlighted, negative for none
+}
+
+// WithMaxDepth only exports the levels of the tree down to the given depth, the root being on depth 0.
+// Interior nodes on the last exported level are marked as truncated. A negative depth exports all levels,
+// which is the default.
+func WithMaxDepth(depth int) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.maxDepth = depth
+	}
+}
+
+// WithProofPath highlights the proof path of the leaf at the given index: the nodes from the root down to
+// the leaf and their siblings, which make up the proof. The path is exported down to the leaf regardless of
+// the depth limit, so large trees are best inspected with both options. A negative index highlights nothing.
+func WithProofPath(leafIdx int) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.proofLeaf = leafIdx
+	}
+}
+
+// newExportConfig applies the options for a tree over `leafCount` leaves.
+func newExportConfig(opts []ExportOption, leafCount int) (*exportConfig, error) {
+	cfg := &exportConfig{maxDepth: -1, proofLeaf: -1}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.proofLeaf >= leafCount {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+	return cfg, nil
+}
+
+// onPath reports whether the node covering the leaves `[l, r]` is on the highlighted proof path.
+func (cfg *exportConfig) onPath(l, r int) bool {
+	return cfg.proofLeaf >= 0 && l <= cfg.proofLeaf && cfg.proofLeaf <= r
+}
+
+// expands reports whether the children of the node covering the leaves `[l, r]` on the given depth are exported.
+func (cfg *exportConfig) expands(l, r, depth int) bool {
+	return cfg.maxDepth < 0 || depth < cfg.maxDepth || cfg.onPath(l, r)
+}
+
+// exportNode is a node as it is exported. Its JSON form is the output of `FormatJSON`.
+type exportNode struct {
+	Hash      string      `json:"hash"`
+	LeftIdx   int         `json:"left_idx"`
+	RightIdx  int         `json:"right_idx"`
+	Proof     string      `json:"proof,omitempty"`     // Role on the highlighted proof path
+	Truncated bool        `json:"truncated,omitempty"` // Interior node whose children are not exported
+	Left      *exportNode `json:"left,omitempty"`
+	Right     *exportNode `json:"right,omitempty"`
+}
+
+func newExportNode(cfg *exportConfig, digest []byte, l, r int, parentOnPath bool) *exportNode {
+	node := &exportNode{Hash: EncodeHash(digest), LeftIdx: l, RightIdx: r}
+	switch {
+	case cfg.onPath(l, r):
+		node.Proof = proofPath
+	case parentOnPath:
+		node.Proof = proofSibling
+	}
+	return node
+}
+
+// Export writes the Merkle tree in the given format to `w`.
+func (mt *MerkleTree) Export(w io.Writer, format ExportFormat, opts ...ExportOption) error {
+	if mt.GetMerkleRoot() == nil {
+		return mterr.ErrEmptyRoot
+	}
+
+	cfg, err := newExportConfig(opts, mt.LeafCount())
+	if err != nil {
+		return err
+	}
+
+	var walk func(n nodeRef, parentOnPath bool) *exportNode
+	walk = func(n nodeRef, parentOnPath bool) *exportNode {
+		node := newExportNode(cfg, mt.digest(n), n.l, n.r, parentOnPath)
+		if n.l == n.r {
+			return node
+		}
+
+		if !cfg.expands(n.l, n.r, n.depth) {
+			node.Truncated = true
+			return node
+		}
+		left, right := mt.children(n)
+		node.Left = walk(left, node.Proof == proofPath)
+		node.Right = walk(right, node.Proof == proofPath)
+		return node
+	}
+	return writeExport(w, format, walk(mt.rootRef(), false))
+}
+
+// Export writes the tree below `root` in the given format to `w`. Unlike `MerkleTree.Export` it also
+// exports partial trees like the ones assembled by `FetchTree`, whose interior nodes without children
+// are marked as truncated.
+func Export(w io.Writer, root *TreeNode, format ExportFormat, opts ...ExportOption) error {
+	if root == nil {
+		return mterr.ErrEmptyRoot
+	}
+
+	cfg, err := newExportConfig(opts, root.RightIdx+1)
+	if err != nil {
+		return err
+	}
+
+	var walk func(n *TreeNode, depth int, parentOnPath bool) *exportNode
+	walk = func(n *TreeNode, depth int, parentOnPath bool) *exportNode {
+		node := newExportNode(cfg, n.Hash, n.LeftIdx, n.RightIdx, parentOnPath)
+		if n.LeftIdx == n.RightIdx {
+			return node
+		}
+
+		if n.Left == nil || n.Right == nil || !cfg.expands(n.LeftIdx, n.RightIdx, depth) {
+			node.Truncated = true
+			return node
+		}
+		node.Left = walk(n.Left, depth+1, node.Proof == proofPath)
+		node.Right = walk(n.Right, depth+1, node.Proof == proofPath)
+		return node
+	}
+	return writeExport(w, format, walk(root, 0, false))
+}
+
+// FetchTree assembles the tree over `leafCount` leaves built with the given scheme from a remote tree,
+// which is only accessible through `getNodes` like in `DiffLevels`. The tree is requested level by level
+// and only the nodes an export with the same options covers are requested, hence the children of the
+// nodes cut off by the depth limit are missing. The nodes are not verified against any root hash.
+func FetchTree(getNodes func(level, from, to int) ([]*TreeNode, error), leafCount int, scheme Scheme, opts ...ExportOption) (*TreeNode, error) {
+	if leafCount <= 0 {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	schemeOpts, err := scheme.Options()
+	if err != nil {
+		return nil, err
+	}
+	treeCfg := newConfig(schemeOpts)
+
+	cfg, err := newExportConfig(opts, leafCount)
+	if err != nil {
+		return nil, err
+	}
+
+	widths := newLevelWidths(treeCfg)
+	root := &TreeNode{LeftIdx: 0, RightIdx: leafCount - 1}
+	nodes := []*TreeNode{root}
+	for level := 0; len(nodes) > 0; level++ {
+		refs := make([]nodeRef, len(nodes))
+		for idx, node := range nodes {
+			refs[idx] = nodeRef{l: node.LeftIdx, r: node.RightIdx, depth: level}
+		}
+
+		remote, err := widths.getLevel(getNodes, level, leafCount, refs)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []*TreeNode
+		for idx, node := range nodes {
+			node.Hash = remote[idx].Hash
+			if node.LeftIdx == node.RightIdx || !cfg.expands(node.LeftIdx, node.RightIdx, level) {
+				continue
+			}
+
+			mid := treeCfg.split(node.LeftIdx, node.RightIdx)
+			node.Left = &TreeNode{LeftIdx: node.LeftIdx, RightIdx: mid}
+			node.Right = &TreeNode{LeftIdx: mid + 1, RightIdx: node.RightIdx}
+			next = append(next, node.Left, node.Right)
+		}
+		nodes = next
+	}
+	return root, nil
+}
+
+// writeExport renders the exported nodes in the given format.
+func writeExport(w io.Writer, format ExportFormat, root *exportNode) error {
+	switch format {
+	case FormatDOT:
+		return writeDOT(w, root)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(root)
+	case FormatMermaid:
+		return writeMermaid(w, root)
+	default:
+		return fmt.Errorf("%w: %q", mterr.ErrUnknownExportFormat, format)
+	}
+}
+
+// writeDOT renders the nodes as Graphviz digraph. Nodes on the proof path are outlined in red, proof
+// siblings are filled in blue and truncated nodes are dashed.
+func writeDOT(w io.Writer, root *exportNode) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph merkle {")
+	fmt.Fprintln(bw, "\tnode [shape=box, fontname=\"monospace\"];")
+
+	root.walk(func(n *exportNode) {
+		var attrs, styles []string
+		switch n.Proof {
+		case proofPath:
+			attrs = append(attrs, "color=\"#d62728\"", "penwidth=2")
+		case proofSibling:
+			attrs = append(attrs, "fillcolor=\"#aec7e8\"")
+			styles = append(styles, "filled")
+		}
+		if n.Truncated {
+			styles = append(styles, "dashed")
+		}
+		if len(styles) > 0 {
+			attrs = append(attrs, fmt.Sprintf("style=\"%s\"", strings.Join(styles, ",")))
+		}
+
+		fmt.Fprintf(bw, "\t%s [label=\"%s\"", n.id(), n.label("\\n"))
+		for _, attr := range attrs {
+			fmt.Fprintf(bw, ", %s", attr)
+		}
+		fmt.Fprintln(bw, "];")
+		if n.Left != nil {
+			fmt.Fprintf(bw, "\t%s -> %s;\n\t%s -> %s;\n", n.id(), n.Left.id(), n.id(), n.Right.id())
+		}
+	})
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// writeMermaid renders the nodes as top-down Mermaid flowchart with the same highlighting as `writeDOT`.
+func writeMermaid(w io.Writer, root *exportNode) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "graph TD")
+
+	classes := make(map[string][]string)
+	root.walk(func(n *exportNode) {
+		fmt.Fprintf(bw, "\t%s[\"%s\"]\n", n.id(), n.label("<br/>"))
+		if n.Left != nil {
+			fmt.Fprintf(bw, "\t%s --> %s\n\t%s --> %s\n", n.id(), n.Left.id(), n.id(), n.Right.id())
+		}
+		if n.Proof != "" {
+			classes[n.Proof] = append(classes[n.Proof], n.id())
+		}
+		if n.Truncated {
+			classes["truncated"] = append(classes["truncated"], n.id())
+		}
+	})
+
+	for _, class := range []struct{ name, style string }{
+		{proofPath, "stroke:#d62728,stroke-width:3px"},
+		{proofSibling, "fill:#aec7e8"},
+		{"truncated", "stroke-dasharray:5 5"},
+	} {
+		if ids := classes[class.name]; len(ids) > 0 {
+			fmt.Fprintf(bw, "\tclassDef %s %s\n", class.name, class.style)
+			fmt.Fprintf(bw, "\tclass %s %s\n", strings.Join(ids, ","), class.name)
+		}
+	}
+	return bw.Flush()
+}
+
+// walk calls `fn` for the node and all exported nodes below it in pre-order.
+func (n *exportNode) walk(fn func(*exportNode)) {
+	fn(n)
+	if n.Left != nil {
+		n.Left.walk(fn)
+		n.Right.walk(fn)
+	}
+}
+
+// id returns the identifier of the node in the graph formats. The leaves a node covers identify it within its tree.
+func (n *exportNode) id() string {
+	return fmt.Sprintf("n%d_%d", n.LeftIdx, n.RightIdx)
+}
+
+// label returns the leaves the node covers and the first 8 hexadecimal digits of its digest separated by `sep`.
+func (n *exportNode) label(sep string) string {
+	return fmt.Sprintf("[%d, %d]%s%s", n.LeftIdx, n.RightIdx, sep, n.Hash[:min(8, len(n.Hash))])
+}
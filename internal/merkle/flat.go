@@ -0,0 +1,231 @@
+package merkle
+
+import (
+	"fmt"
+	"math/bits"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// Layout selects how the nodes of a Merkle tree are stored in memory.
+// Both layouts produce identical roots and proofs.
+type Layout int
+
+const (
+	// LayoutPointer stores every node as a heap allocated `TreeNode` linked to its children.
+	LayoutPointer Layout = iota
+
+	// LayoutFlat stores the digests of every level of the tree in one contiguous byte slice
+	// without any per-node pointers, which keeps the garbage collector out of the way for
+	// trees with millions of leaves. It is only available for the `ShapeMidpoint` shape.
+	LayoutFlat
+)
+
+// WithLayout selects the storage layout of the tree. Trees use `LayoutPointer` by default.
+func WithLayout(layout Layout) Option {
+	return func(cfg *config) {
+		cfg.layout = layout
+	}
+}
+
+// flatTree is the array-backed storage of a Merkle tree.
+//
+// The segment tree split keeps the sizes of all nodes on the same depth within one of each other.
+// Hence with `h = ceil(log2(n))`, every depth `d < h` is complete and holds `2^d` nodes, while the
+// last depth `h` only holds the children of the nodes covering two leaves on depth `h-1`. The node
+// at position `p` on depth `d` has its children at positions `2p` and `2p+1` on depth `d+1`, except
+// on depth `h-1`, where the children of the node covering the leaves `[l, l+1]` sit at `2(l-p)` and
+// `2(l-p)+1`, `l-p` being the number of two-leaf nodes to its left. All in all the levels hold
+// exactly `2n-1` digests.
+type flatTree struct {
+	leafCount int      // Number of leaves
+	size      int      // Digest size in bytes
+	levels    [][]byte // levels[d] holds the digests of all nodes on depth d from left to right
+}
+
+// buildFlatTree builds the array-backed Merkle tree above the given leaf digests.
+// The level arithmetic relies on the `ShapeMidpoint` split.
+func buildFlatTree(cfg *config, f *forker, leaves [][]byte) *flatTree {
+	ft := newFlatTree(cfg, len(leaves))
+	ft.build(cfg, f, leaves, nodeRef{l: 0, r: len(leaves) - 1})
+	return ft
+}
+
+// newFlatTree allocates the levels of a flat tree over `n` leaves with all digests zeroed.
+func newFlatTree(cfg *config, n int) *flatTree {
+	height := bits.Len(uint(n - 1))
+	ft := &flatTree{
+		leafCount: n,
+		size:      cfg.hasher.Size(),
+		levels:    make([][]byte, height+1),
+	}
+
+	for d := 0; d < height; d++ {
+		ft.levels[d] = make([]byte, (1<<d)*ft.size)
+	}
+	ft.levels[height] = make([]byte, (2*n-(1<<height))*ft.size)
+	return ft
+}
+
+// build recursively computes the digest of the given node and of all nodes below it.
+// Sibling subtrees write disjoint parts of the levels, so they may be built concurrently.
+func (ft *flatTree) build(cfg *config, f *forker, leaves [][]byte, n nodeRef) []byte {
+	var digest []byte
+	if n.l == n.r {
+		digest = leaves[n.l]
+	} else {
+		left, right := ft.children(n)
+		var leftDigest []byte
+		wait := f.fork(left.r-left.l+1, func() {
+			leftDigest = ft.build(cfg, f, leaves, left)
+		})
+		rightDigest := ft.build(cfg, f, leaves, right)
+		wait()
+		digest = cfg.hashNode(leftDigest, rightDigest)
+	}
+
+	copy(ft.digest(n), digest)
+	return ft.digest(n)
+}
+
+// update overwrites the digest of the leaf at `leafIdx` and recomputes the digests of its ancestors in place.
+func (ft *flatTree) update(cfg *config, leafIdx int, leaf []byte) {
+	path := []nodeRef{{l: 0, r: ft.leafCount - 1}}
+	for curr := path[0]; curr.l != curr.r; curr = path[len(path)-1] {
+		left, right := ft.children(curr)
+		if leafIdx <= left.r {
+			path = append(path, left)
+		} else {
+			path = append(path, right)
+		}
+	}
+
+	copy(ft.digest(path[len(path)-1]), leaf)
+	for i := len(path) - 2; i >= 0; i-- {
+		left, right := ft.children(path[i])
+		copy(ft.digest(path[i]), cfg.hashNode(ft.digest(left), ft.digest(right)))
+	}
+}
+
+// digest returns the digest of the given node. The returned slice aliases the level storage.
+func (ft *flatTree) digest(n nodeRef) []byte {
+	offset := n.pos * ft.size
+	return ft.levels[n.depth][offset : offset+ft.size : offset+ft.size]
+}
+
+// children returns the left and right child of the given interior node.
+func (ft *flatTree) children(n nodeRef) (nodeRef, nodeRef) {
+	mid := n.l + (n.r-n.l)/2
+	pos := 2 * n.pos
+	if n.depth == len(ft.levels)-2 {
+		pos = 2 * (n.l - n.pos)
+	}
+	return nodeRef{l: n.l, r: mid, depth: n.depth + 1, pos: pos},
+		nodeRef{l: mid + 1, r: n.r, depth: n.depth + 1, pos: pos + 1}
+}
+
+// nodeRef addresses a node independently of the storage layout of the tree.
+type nodeRef struct {
+	l, r  int       // Range of leaves covered by the node
+	depth int       // Distance from the root
+	pos   int       // Position on its depth in the flat layout
+	ptr   *TreeNode // Node in the pointer layout
+}
+
+// rootRef returns the reference to the root node.
+func (mt *MerkleTree) rootRef() nodeRef {
+	if mt.flat != nil {
+		return nodeRef{l: 0, r: mt.flat.leafCount - 1}
+	}
+	if mt.mmr != nil {
+		return nodeRef{l: 0, r: mt.mmr.leafCount - 1}
+	}
+	return nodeRef{l: mt.root.LeftIdx, r: mt.root.RightIdx, ptr: mt.root}
+}
+
+// children returns the left and right child of the given interior node.
+func (mt *MerkleTree) children(n nodeRef) (nodeRef, nodeRef) {
+	if mt.flat != nil {
+		return mt.flat.children(n)
+	}
+	if mt.mmr != nil {
+		mid := mt.cfg.split(n.l, n.r)
+		return nodeRef{l: n.l, r: mid, depth: n.depth + 1}, nodeRef{l: mid + 1, r: n.r, depth: n.depth + 1}
+	}
+	left, right := n.ptr.Left, n.ptr.Right
+	return nodeRef{l: left.LeftIdx, r: left.RightIdx, depth: n.depth + 1, ptr: left},
+		nodeRef{l: right.LeftIdx, r: right.RightIdx, depth: n.depth + 1, ptr: right}
+}
+
+// digest returns the digest of the given node.
+func (mt *MerkleTree) digest(n nodeRef) []byte {
+	if mt.flat != nil {
+		return mt.flat.digest(n)
+	}
+	if mt.mmr != nil {
+		return mt.mmr.digest(n)
+	}
+	return n.ptr.Hash
+}
+
+// treeNode returns the given node as `TreeNode`. The flat layout has no `TreeNode` objects,
+// so a detached copy of the node is materialized together with its direct children.
+func (mt *MerkleTree) treeNode(n nodeRef) *TreeNode {
+	if n.ptr != nil {
+		return n.ptr
+	}
+
+	node := &TreeNode{
+		Hash:     append([]byte(nil), mt.digest(n)...),
+		LeftIdx:  n.l,
+		RightIdx: n.r,
+	}
+	if n.l != n.r {
+		left, right := mt.children(n)
+		node.Left = &TreeNode{Hash: append([]byte(nil), mt.digest(left)...), LeftIdx: left.l, RightIdx: left.r}
+		node.Right = &TreeNode{Hash: append([]byte(nil), mt.digest(right)...), LeftIdx: right.l, RightIdx: right.r}
+	}
+	return node
+}
+
+// genRefProof generates a Merkle proof for the given leaf index on any storage layout.
+// Like `genProof` it descends from the root and collects one sibling per level.
+func genRefProof(mt *MerkleTree, leafIdx int) ([]*TreeNode, error) {
+	curr := mt.rootRef()
+	if leafIdx < curr.l || leafIdx > curr.r {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	var result []*TreeNode
+	for curr.l != curr.r {
+		left, right := mt.children(curr)
+		if leafIdx <= left.r {
+			result = append(result, mt.treeNode(right))
+			curr = left
+		} else {
+			result = append(result, mt.treeNode(left))
+			curr = right
+		}
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// printRefTree prints the Merkle tree in the same format as `printTree` on any storage layout.
+func printRefTree(mt *MerkleTree, n nodeRef, prefix string, isLeft bool) {
+	fmt.Printf("%s", prefix)
+	if isLeft {
+		fmt.Printf("├── L ")
+	} else {
+		fmt.Printf("└── R ")
+	}
+	fmt.Printf("(%d, %d) ==> %x \n", n.l, n.r, mt.digest(n))
+	if n.l != n.r {
+		left, right := mt.children(n)
+		printRefTree(mt, left, prefix+"│   ", true)
+		printRefTree(mt, right, prefix+"    ", false)
+	}
+}
@@ -0,0 +1,165 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// Names of the supported hash algorithms as recorded alongside the merkle root hash
+// and carried in the gRPC messages.
+const (
+	SHA256     = "sha256"
+	SHA512_256 = "sha512_256"
+	SHA3_256   = "sha3_256"
+	BLAKE2b256 = "blake2b_256"
+)
+
+// Hasher computes the digests the Merkle tree is built from.
+// Trees are built concurrently, so implementations must be safe for concurrent use.
+type Hasher interface {
+	// Name returns the name of the hash algorithm, e.g. `sha256`.
+	Name() string
+
+	// Size returns the length of the digest in bytes.
+	Size() int
+
+	// Sum returns the digest of the given data.
+	Sum(data []byte) []byte
+}
+
+// stdHasher adapts a `hash.Hash` constructor to the `Hasher` interface.
+type stdHasher struct {
+	name    string
+	size    int
+	newHash func() hash.Hash
+}
+
+func (h *stdHasher) Name() string { return h.name }
+
+func (h *stdHasher) Size() int { return h.size }
+
+// New returns a fresh hash, which lets files be hashed while they are read.
+func (h *stdHasher) New() hash.Hash { return h.newHash() }
+
+func (h *stdHasher) Sum(data []byte) []byte {
+	hh := h.newHash()
+	hh.Write(data)
+	return hh.Sum(nil)
+}
+
+var hashers = map[string]Hasher{
+	SHA256:     &stdHasher{name: SHA256, size: sha256.Size, newHash: sha256.New},
+	SHA512_256: &stdHasher{name: SHA512_256, size: sha512.Size256, newHash: sha512.New512_256},
+	SHA3_256:   &stdHasher{name: SHA3_256, size: 32, newHash: sha3.New256},
+	BLAKE2b256: &stdHasher{name: BLAKE2b256, size: blake2b.Size256, newHash: newBLAKE2b256},
+}
+
+// newBLAKE2b256 returns an unkeyed BLAKE2b-256 hash.
+func newBLAKE2b256() hash.Hash {
+	// Creating an unkeyed BLAKE2b hash never fails
+	h, _ := blake2b.New256(nil)
+	return h
+}
+
+// DefaultHasher returns the SHA-256 hasher which all merkle trees used before the hash algorithm became configurable.
+func DefaultHasher() Hasher {
+	return hashers[SHA256]
+}
+
+// HasherByName returns the hasher for the given hash algorithm name.
+// An empty name selects the default SHA-256 hasher, so that requests and root hash records
+// written before the hash algorithm became configurable keep working.
+func HasherByName(name string) (Hasher, error) {
+	if name == "" {
+		return DefaultHasher(), nil
+	}
+
+	h, ok := hashers[name]
+	if !ok {
+		return nil, mterr.ErrUnknownHashAlgorithm
+	}
+	return h, nil
+}
+
+// HashAlgorithms returns the names of all supported hash algorithms.
+func HashAlgorithms() []string {
+	return []string{SHA256, SHA512_256, SHA3_256, BLAKE2b256}
+}
+
+// Prefixes prepended to the hashed data when domain separation is enabled (RFC 6962, section 2.1).
+const (
+	leafPrefix byte = 0x00
+	nodePrefix byte = 0x01
+)
+
+// hashLeaf calculates the digest of a leaf from the file content.
+func (cfg *config) hashLeaf(file []byte) []byte {
+	if !cfg.domainSeparation {
+		return cfg.hasher.Sum(file)
+	}
+	data := make([]byte, 0, 1+len(file))
+	data = append(data, leafPrefix)
+	data = append(data, file...)
+	return cfg.hasher.Sum(data)
+}
+
+// hashLeafFrom calculates the digest of a leaf from the file content read until EOF. Files are
+// streamed into the hash if the hasher provides a `hash.Hash`, otherwise they are read into memory.
+func (cfg *config) hashLeafFrom(r io.Reader) ([]byte, error) {
+	streamer, ok := cfg.hasher.(interface{ New() hash.Hash })
+	if !ok {
+		file, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return cfg.hashLeaf(file), nil
+	}
+
+	h := streamer.New()
+	if cfg.domainSeparation {
+		h.Write([]byte{leafPrefix})
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// hashNode calculates the digest of an interior node from the digests of its left and right child.
+// `VersionHex` trees hash the hexadecimal encoding of the child digests, `VersionBinary` trees the raw digests.
+func (cfg *config) hashNode(left, right []byte) []byte {
+	if cfg.version == VersionHex {
+		left = []byte(hex.EncodeToString(left))
+		right = []byte(hex.EncodeToString(right))
+	}
+
+	data := make([]byte, 0, 1+len(left)+len(right))
+	if cfg.domainSeparation {
+		data = append(data, nodePrefix)
+	}
+	data = append(data, left...)
+	data = append(data, right...)
+	return cfg.hasher.Sum(data)
+}
+
+// EncodeHash returns the hexadecimal form digests take at the gRPC, CLI and JSON boundaries.
+func EncodeHash(digest []byte) string {
+	return hex.EncodeToString(digest)
+}
+
+// DecodeHash parses a hexadecimal digest received at the gRPC, CLI or JSON boundaries.
+func DecodeHash(s string) ([]byte, error) {
+	digest, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, mterr.ErrInvalidHash
+	}
+	return digest, nil
+}
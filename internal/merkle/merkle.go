@@ -1,16 +1,18 @@
 package merkle
 
 import (
-	"crypto/sha256"
+	"bytes"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"time"
 
 	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
 )
 
 // TreeNode represents a node in the Merkle tree.
 type TreeNode struct {
-	Hash     string    // Hash value of the node
+	Hash     []byte    // Digest of the node, its length depends on the hash algorithm
 	LeftIdx  int       // Left index of the node
 	RightIdx int       // Right index of the node
 	Left     *TreeNode // Left child node
@@ -19,103 +21,271 @@ type TreeNode struct {
 
 // MerkleTree represents a Merkle tree.
 type MerkleTree struct {
-	root *TreeNode // Root node of the Merkle tree
+	root *TreeNode      // Root node of the Merkle tree in the pointer layout
+	flat *flatTree      // Digests of the Merkle tree in the flat layout
+	mmr  *mountainRange // Digests of the Merkle tree with the `ShapeMountainRange` shape
+	cfg  *config        // Settings the tree is built with
+
+	buildDuration time.Duration // Time `BuildMerkleTree` took to hash the files and build the tree
+}
+
+// Option configures how a Merkle tree is built and how proofs are verified.
+type Option func(*config)
+
+// config holds the settings applied by the options.
+type config struct {
+	hasher           Hasher
+	domainSeparation bool
+	version          Version
+	shape            Shape
+	layout           Layout
+	workers          int
+	chunkSize        int
+	chunking         Chunking
+}
+
+// WithHasher selects the hash algorithm. Trees are built with SHA-256 by default.
+func WithHasher(h Hasher) Option {
+	return func(cfg *config) {
+		if h != nil {
+			cfg.hasher = h
+		}
+	}
+}
+
+// WithDomainSeparation prefixes leaf hashes with 0x00 and interior node hashes with 0x01 as
+// RFC 6962 does, so that an interior node can never be presented as a leaf. Trees are built
+// without the prefixes by default, which keeps the roots of existing trees verifiable.
+func WithDomainSeparation(enabled bool) Option {
+	return func(cfg *config) {
+		cfg.domainSeparation = enabled
+	}
+}
+
+// WithVersion selects how interior nodes combine the digests of their children.
+// Trees are built with `VersionHex` by default, which keeps the roots of existing trees verifiable.
+func WithVersion(version Version) Option {
+	return func(cfg *config) {
+		if version != 0 {
+			cfg.version = version
+		}
+	}
 }
 
-// BuildMerkleTree builds a Merkle tree from the given file data.
-func BuildMerkleTree(file [][]byte) (*MerkleTree, error) {
+// newConfig applies the options on top of the defaults.
+func newConfig(opts []Option) *config {
+	cfg := &config{hasher: DefaultHasher(), version: VersionHex, shape: ShapeMidpoint, chunking: ChunkingFixed}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// BuildMerkleTree builds a Merkle tree from the given file data. Files can only be appended to trees with the
+// `ShapeRFC6962`, `ShapeMountainRange` or `ShapeBitcoin` shape later on, see `Append`.
+func BuildMerkleTree(file [][]byte, opts ...Option) (*MerkleTree, error) {
 	log.Println("[merkle-tree] starting to build merkle trees")
 	n := len(file)
 	if n == 0 {
 		return nil, mterr.ErrEmptyFile
 	}
 
-	l, r := 0, n-1
-	root := buildTree(file, l, r)
-	return &MerkleTree{root: root}, nil
+	cfg := newConfig(opts)
+	if cfg.layout == LayoutFlat && cfg.shape != ShapeMidpoint {
+		return nil, mterr.ErrUnsupportedLayout
+	}
+
+	start := time.Now()
+	mt := &MerkleTree{cfg: cfg}
+	mt.build(hashLeaves(cfg, file))
+	mt.buildDuration = time.Since(start)
+	return mt, nil
+}
+
+// BuildMerkleTreeFromLeaves builds the Merkle tree over the leaf digests of files, each calculated with
+// `LeafHash` and the same options. This lets files be hashed and dropped one at a time as they arrive
+// rather than held in memory until the tree is built.
+func BuildMerkleTreeFromLeaves(leaves [][]byte, opts ...Option) (*MerkleTree, error) {
+	if len(leaves) == 0 {
+		return nil, mterr.ErrEmptyFile
+	}
+
+	cfg := newConfig(opts)
+	if cfg.layout == LayoutFlat && cfg.shape != ShapeMidpoint {
+		return nil, mterr.ErrUnsupportedLayout
+	}
+	for _, leaf := range leaves {
+		if len(leaf) != cfg.hasher.Size() {
+			return nil, mterr.ErrInvalidHash
+		}
+	}
+
+	start := time.Now()
+	mt := &MerkleTree{cfg: cfg}
+	mt.build(leaves)
+	mt.buildDuration = time.Since(start)
+	return mt, nil
+}
+
+// build (re)builds the nodes of the Merkle tree above the given leaf digests in the configured layout.
+// Large subtrees are built concurrently by up to the configured number of workers.
+func (mt *MerkleTree) build(leaves [][]byte) {
+	f := newForker(mt.cfg)
+	switch {
+	case mt.cfg.shape == ShapeMountainRange:
+		mt.root, mt.flat, mt.mmr = nil, nil, buildMountainRange(mt.cfg, leaves)
+	case mt.cfg.layout == LayoutFlat:
+		mt.root, mt.flat, mt.mmr = nil, buildFlatTree(mt.cfg, f, leaves), nil
+	default:
+		l, r := 0, len(leaves)-1
+		mt.root, mt.flat, mt.mmr = buildTree(mt.cfg, f, leaves, l, r), nil, nil
+	}
+}
+
+// Append adds the files as new rightmost leaves to the Merkle tree in the given order.
+// Trees with the `ShapeRFC6962` or the `ShapeBitcoin` shape only recompute the O(log n) nodes
+// on the path from every new leaf to the root, trees with the `ShapeMountainRange` shape only add
+// the new nodes and rebag the peaks once. With the `ShapeMidpoint` shape, and hence in the flat
+// layout, the split point of almost every node moves, so such trees cannot be appended to and
+// `ErrUnsupportedShape` is returned. They are rebuilt over all files instead.
+func (mt *MerkleTree) Append(files ...[]byte) error {
+	if !mt.cfg.powerOfTwoSplit() {
+		return mterr.ErrUnsupportedShape
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	leaves := hashLeaves(mt.cfg, files)
+	if mt.mmr != nil {
+		for _, leaf := range leaves {
+			mt.mmr.push(mt.cfg, leaf)
+		}
+		mt.mmr.bag(mt.cfg)
+		return nil
+	}
+
+	for _, leaf := range leaves {
+		mt.root = appendLeaf(mt.cfg, mt.root, leaf)
+	}
+	return nil
+}
+
+// UpdateLeaf replaces the file stored at the given leaf index. Only the O(log n) nodes on the path
+// from the leaf to the root are recomputed. In the pointer layout they are copied instead of modified,
+// so proofs handed out before the update stay intact.
+func (mt *MerkleTree) UpdateLeaf(leafIdx int, file []byte) error {
+	if leafIdx < 0 || leafIdx >= mt.LeafCount() {
+		return mterr.ErrIndexOutOfBound
+	}
+
+	leaf := mt.cfg.hashFile(file)
+	if mt.flat != nil {
+		mt.flat.update(mt.cfg, leafIdx, leaf)
+		return nil
+	}
+	if mt.mmr != nil {
+		mt.mmr.update(mt.cfg, leafIdx, leaf)
+		return nil
+	}
+	mt.root = updateLeaf(mt.cfg, mt.root, leafIdx, leaf)
+	return nil
 }
 
 // GenerateMerkleProof generates a Merkle proof for the given leaf index.
 func (mt *MerkleTree) GenerateMerkleProof(leafIdx int) ([]*TreeNode, error) {
 	log.Printf("[merkle-tree] starting to generate merkle proof for file index %d with root %T \n", leafIdx, mt.root)
+	if mt.root == nil {
+		return genRefProof(mt, leafIdx)
+	}
 	return genProof(mt.root, leafIdx)
 }
 
-// VerifyMerkleProof verifies the Merkle proof for the given file data and leaf index.
-func (mt *MerkleTree) VerifyMerkleProof(rootHash, fileHash string, fileIdx int, proofs []*TreeNode) (bool, error) {
-	log.Printf("[merkle-tree] verifying merkle proof for file index %d with merkle root hash %s \n", fileIdx, mt.root.Hash)
-	if mt.root == nil {
+// VerifyMerkleProof verifies the Merkle proof for the given leaf hash and leaf index against the tree.
+// Like `VerifyProof`, whether each sibling sits to the left or to the right is derived from `fileIdx` and
+// the number of leaves of the tree alone. The indices the proof nodes carry are ignored, and proofs that
+// do not have one sibling per level on the path of the leaf are rejected with `ErrInvalidProof`.
+func (mt *MerkleTree) VerifyMerkleProof(rootHash, fileHash []byte, fileIdx int, proofs []*TreeNode) (bool, error) {
+	if mt.root == nil && mt.flat == nil && mt.mmr == nil {
 		return false, mterr.ErrEmptyRoot
 	}
+	root := mt.rootRef()
+	log.Printf("[merkle-tree] verifying merkle proof for file index %d with merkle root hash %x \n", fileIdx, mt.digest(root))
 
-	if mt.root.Hash != rootHash {
+	if !bytes.Equal(mt.digest(root), rootHash) {
 		return false, mterr.ErrMerkleRootHashMisMatch
 	}
 
-	merkleHash := fileHash
-	leaf, err := findLeaf(mt.root, fileIdx)
-	if err != nil {
-		return false, err
-	}
-
-	if leaf == nil {
+	leafCount := mt.LeafCount()
+	if fileIdx < 0 || fileIdx >= leafCount {
 		return false, mterr.ErrIndexOutOfBound
 	}
 
-	if leaf.Hash != merkleHash {
-		return false, nil
-	}
-
-	// If the root has either a left or right child
-	if mt.root.Left != nil || mt.root.Right != nil {
-		curr := &TreeNode{}
-		*curr = *leaf
-		for _, proof := range proofs {
-			if curr.LeftIdx < proof.LeftIdx && curr.RightIdx < proof.RightIdx {
-				merkleHash = CalcHash(append([]byte(merkleHash), []byte(proof.Hash)...))
-			} else {
-				merkleHash = CalcHash(append([]byte(proof.Hash), []byte(merkleHash)...))
-			}
-			curr.LeftIdx = min(curr.LeftIdx, proof.LeftIdx)
-			curr.RightIdx = max(curr.RightIdx, proof.RightIdx)
-		}
+	merkleHash, err := mt.cfg.rootHash(fileHash, fileIdx, leafCount, proofs)
+	if err != nil {
+		return false, err
 	}
-
-	return mt.root.Hash == merkleHash && rootHash == merkleHash, nil
+	return bytes.Equal(merkleHash, rootHash), nil
 }
 
 // GetMerkleRoot returns the root node of the Merkle tree.
 // If the MerkleTree instance is nil, it returns nil.
+// Trees in the flat layout return a detached copy of the root carrying only its direct children.
 func (mt *MerkleTree) GetMerkleRoot() *TreeNode {
 	if mt == nil {
 		return nil
 	}
+	if mt.root == nil {
+		return mt.treeNode(mt.rootRef())
+	}
 	return mt.root
 }
 
-// PrintTreeInfo prints information about the Merkle tree.
-// It displays the total number of nodes in the tree and its height.
-// Additionally, it prints the Merkle tree structure.
+// LeafCount returns the number of leaves (files) of the Merkle tree.
+func (mt *MerkleTree) LeafCount() int {
+	root := mt.rootRef()
+	return root.r - root.l + 1
+}
+
+// Hasher returns the hash algorithm the Merkle tree is built with.
+func (mt *MerkleTree) Hasher() Hasher {
+	return mt.cfg.hasher
+}
+
+// PrintTreeInfo prints information about the Merkle tree to stdout.
+// It displays the statistics returned by `Stats` and the Merkle tree structure,
+// which floods the output for large trees, so it is meant for debugging.
 func (mt *MerkleTree) PrintTreeInfo() {
+	stats := mt.Stats()
 	fmt.Println(" ******************************** Merkle Tree Metadata ***************************************************************")
-
-	fmt.Printf("Total number of nodes: %d \n", countNodes(mt.root))
-	fmt.Printf("Height of the merkle tree: %d \n", maxDepth(mt.root))
+	fmt.Printf("Total number of nodes: %d \n", stats.NodeCount)
+	fmt.Printf("Height of the merkle tree: %d \n", stats.Height)
+	fmt.Printf("Total size of the digests: %d bytes \n", stats.TotalBytes)
+	fmt.Printf("Hash algorithm: %s \n", stats.HashAlgorithm)
+	fmt.Printf("Build duration: %s \n", stats.BuildDuration)
 
 	fmt.Println(" ******************************** Merkle Tree  ***********************************************************************")
-	printTree(mt.root, "", true)
+	if mt.root == nil {
+		printRefTree(mt, mt.rootRef(), "", true)
+	} else {
+		printTree(mt.root, "", true)
+	}
 }
 
-// buildTree recursively builds the Merkle tree.
-func buildTree(file [][]byte, l, r int) *TreeNode {
+// buildTree recursively builds the Merkle tree above the given leaf digests.
+func buildTree(cfg *config, f *forker, leaves [][]byte, l, r int) *TreeNode {
 	if l == r {
-		return &TreeNode{Hash: CalcHash(file[l]), LeftIdx: l, RightIdx: r}
-	}
-	mid := l + (r-l)/2
-	left := buildTree(file, l, mid)
-	right := buildTree(file, mid+1, r)
+		return &TreeNode{Hash: leaves[l], LeftIdx: l, RightIdx: r}
+	}
+	mid := cfg.split(l, r)
+	var left *TreeNode
+	wait := f.fork(mid-l+1, func() {
+		left = buildTree(cfg, f, leaves, l, mid)
+	})
+	right := buildTree(cfg, f, leaves, mid+1, r)
+	wait()
 	return &TreeNode{
-		Hash:     CalcHash(append([]byte(left.Hash), []byte(right.Hash)...)),
+		Hash:     cfg.hashChildren(l, mid, r, left.Hash, right.Hash),
 		LeftIdx:  l,
 		RightIdx: r,
 		Left:     left,
@@ -123,7 +293,32 @@ func buildTree(file [][]byte, l, r int) *TreeNode {
 	}
 }
 
+// updateLeaf returns a copy of the subtree rooted at `node` in which the leaf at `leafIdx` has the given digest.
+// Only the nodes on the path to the leaf are copied, all other nodes are shared with the original subtree.
+func updateLeaf(cfg *config, node *TreeNode, leafIdx int, leaf []byte) *TreeNode {
+	if node.Left == nil && node.Right == nil {
+		return &TreeNode{Hash: leaf, LeftIdx: node.LeftIdx, RightIdx: node.RightIdx}
+	}
+
+	left, right := node.Left, node.Right
+	if leafIdx <= left.RightIdx {
+		left = updateLeaf(cfg, left, leafIdx, leaf)
+	} else {
+		right = updateLeaf(cfg, right, leafIdx, leaf)
+	}
+	return &TreeNode{
+		Hash:     cfg.hashChildren(node.LeftIdx, left.RightIdx, node.RightIdx, left.Hash, right.Hash),
+		LeftIdx:  node.LeftIdx,
+		RightIdx: node.RightIdx,
+		Left:     left,
+		Right:    right,
+	}
+}
+
 // genProof generates a Merkle proof for the given leaf index.
+// It descends from the root to the leaf, deciding at every node by index arithmetic which child
+// holds the leaf, and collects the other child as sibling. This visits one node per level, so the
+// proof is generated in O(log n). The siblings are returned ordered from the leaf up to the root.
 func genProof(root *TreeNode, leafIdx int) ([]*TreeNode, error) {
 	switch {
 	case root == nil:
@@ -132,34 +327,26 @@ func genProof(root *TreeNode, leafIdx int) ([]*TreeNode, error) {
 		return nil, mterr.ErrIndexOutOfBound
 	}
 
-	if root.Left == nil && root.Right == nil {
-		return []*TreeNode{root}, nil
-	}
-
 	var result []*TreeNode
-	sibling, err := findSiblingByLeafIndex(root, leafIdx)
-	if err != nil {
-		return nil, err
+	curr := root
+	for curr.Left != nil && curr.Right != nil {
+		if leafIdx <= curr.Left.RightIdx {
+			result = append(result, curr.Right)
+			curr = curr.Left
+		} else {
+			result = append(result, curr.Left)
+			curr = curr.Right
+		}
 	}
-	result = append(result, sibling)
 
-	parent, err := findParentByLeafIndex(root, leafIdx)
-	if err != nil {
-		return nil, err
+	// The descent must end at the requested leaf. A single-leaf tree has no siblings at all.
+	if curr.LeftIdx != leafIdx || curr.RightIdx != leafIdx {
+		return nil, mterr.ErrLeafDoesNotExist
 	}
 
-	for parent != root {
-		sibling, err = findSibling(root, parent)
-		if err != nil {
-			return nil, err
-		}
-		result = append(result, sibling)
-		parent, err = findParent(root, parent)
-		if err != nil {
-			return nil, err
-		}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
 	}
-
 	return result, nil
 }
 
@@ -188,101 +375,16 @@ func printTree(node *TreeNode, prefix string, isLeft bool) {
 		} else {
 			fmt.Printf("└── R ")
 		}
-		fmt.Printf("(%d, %d) ==> %s \n", node.LeftIdx, node.RightIdx, node.Hash)
+		fmt.Printf("(%d, %d) ==> %x \n", node.LeftIdx, node.RightIdx, node.Hash)
 		printTree(node.Left, prefix+"│   ", true)
 		printTree(node.Right, prefix+"    ", false)
 	}
 }
 
-// findLeaf finds the leaf node corresponding to the given leaf index.
-func findLeaf(root *TreeNode, leafIdx int) (*TreeNode, error) {
-	switch {
-	case root == nil:
-		return nil, mterr.ErrEmptyFile
-	case leafIdx < root.LeftIdx || leafIdx > root.RightIdx:
-		return nil, mterr.ErrIndexOutOfBound
-	}
-
-	if root.Left == nil && root.Right == nil && root.LeftIdx == leafIdx && root.RightIdx == leafIdx {
-		return root, nil
-	}
-	midIdx := root.LeftIdx + (root.RightIdx-root.LeftIdx)/2
-	if leafIdx <= midIdx {
-		return findLeaf(root.Left, leafIdx)
-	}
-	return findLeaf(root.Right, leafIdx)
-}
-
-// findParent finds the parent node of the given node.
-func findParent(root, node *TreeNode) (*TreeNode, error) {
-
-	switch {
-	case root == nil:
-		return nil, mterr.ErrEmptyRoot
-	case node == nil:
-		return nil, mterr.ErrEmptyNode
-	}
-
-	if root == node {
-		return nil, nil
-	}
-
-	if root.Left == node || root.Right == node {
-		return root, nil
-	}
-	if parent, err := findParent(root.Left, node); parent != nil && err == nil {
-		return parent, nil
-	}
-	return findParent(root.Right, node)
-}
-
-// findSibling finds the sibling node of the given node.
-func findSibling(root, node *TreeNode) (*TreeNode, error) {
-	parent, err := findParent(root, node)
-	if err != nil {
-		return nil, err
-	}
-
-	if parent == nil {
-		return nil, nil
-	}
-	if parent.Left == node {
-		return parent.Right, nil
-	}
-	return parent.Left, nil
-}
-
-// findParentByLeafIndex finds the parent node of the leaf node corresponding to the given leaf index.
-func findParentByLeafIndex(root *TreeNode, leafIdx int) (*TreeNode, error) {
-	leaf, err := findLeaf(root, leafIdx)
-	if err != nil {
-		return nil, err
-	}
-
-	if leaf == root {
-		return nil, nil
-	}
-
-	return findParent(root, leaf)
-}
-
-// findSiblingByLeafIndex finds the sibling node of the leaf node corresponding to the given leaf index.
-func findSiblingByLeafIndex(root *TreeNode, leafIdx int) (*TreeNode, error) {
-	leaf, err := findLeaf(root, leafIdx)
-	if err != nil {
-		return nil, err
-	}
-
-	if leaf == nil || leaf == root {
-		return nil, nil
-	}
-	return findSibling(root, leaf)
-}
-
-// calcHash calculates the SHA-256 hash of the given byte slice and returns it as a hexadecimal string.
+// CalcHash calculates the SHA-256 hash of the given byte slice and returns it as a hexadecimal string.
+// Use `LeafHash` for the leaf digests of trees built with a different scheme.
 func CalcHash(file []byte) string {
-	hash := sha256.Sum256(file)
-	return fmt.Sprintf("%x", hash)
+	return hex.EncodeToString(DefaultHasher().Sum(file))
 }
 
 // countNodes counts the total number of nodes in the Merkle tree.
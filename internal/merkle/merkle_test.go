@@ -1,7 +1,19 @@
 package merkle
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"math"
+	"math/bits"
+	"math/rand"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 
 	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
@@ -87,7 +99,7 @@ func TestMerkleTree(t *testing.T) {
 			for idx, file := range test.files {
 				merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
 				require.NoError(t, err)
-				isVerified, err := merkleTree.VerifyMerkleProof(merkleTree.root.Hash, CalcHash(file), idx, merkleProofs)
+				isVerified, err := merkleTree.VerifyMerkleProof(merkleTree.root.Hash, merkleTree.LeafHash(file), idx, merkleProofs)
 				require.NoError(t, err)
 				require.True(t, isVerified, "merkle proof verification failed for test %s at file index %d \n", test.name, idx)
 			}
@@ -96,7 +108,7 @@ func TestMerkleTree(t *testing.T) {
 			for idx := len(test.files) - 1; idx >= 0; idx-- {
 				merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
 				require.NoError(t, err)
-				isVerified, err := merkleTree.VerifyMerkleProof(merkleTree.root.Hash, CalcHash(test.files[idx]), idx, merkleProofs)
+				isVerified, err := merkleTree.VerifyMerkleProof(merkleTree.root.Hash, merkleTree.LeafHash(test.files[idx]), idx, merkleProofs)
 				require.NoError(t, err)
 				require.True(t, isVerified, "merkle proof verification failed for test %s at file index %d \n", test.name, idx)
 			}
@@ -105,6 +117,2110 @@ func TestMerkleTree(t *testing.T) {
 	}
 }
 
+func TestVerifyProof(t *testing.T) {
+	var files [][]byte
+	for i := 0; i < 26; i++ {
+		files = append(files, []byte{byte('A' + i)})
+	}
+
+	for n := 1; n <= len(files); n++ {
+		merkleTree, err := BuildMerkleTree(files[:n])
+		require.NoError(t, err)
+		rootHash := merkleTree.GetMerkleRoot().Hash
+
+		for idx := 0; idx < n; idx++ {
+			merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+			require.NoError(t, err)
+
+			isVerified, err := VerifyProof(rootHash, files[idx], idx, n, merkleProofs)
+			require.NoError(t, err)
+			require.True(t, isVerified, "offline verification failed for %d files at file index %d", n, idx)
+
+			// A tampered file must not verify against the same proof
+			isVerified, err = VerifyProof(rootHash, []byte("tampered"), idx, n, merkleProofs)
+			require.NoError(t, err)
+			require.False(t, isVerified)
+		}
+	}
+
+	merkleTree, err := BuildMerkleTree(files[:5])
+	require.NoError(t, err)
+	merkleProofs, err := merkleTree.GenerateMerkleProof(2)
+	require.NoError(t, err)
+	rootHash := merkleTree.GetMerkleRoot().Hash
+
+	_, err = VerifyProof(rootHash, files[2], 5, 5, merkleProofs)
+	require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+
+	_, err = VerifyProof(rootHash, files[2], 2, 5, merkleProofs[:1])
+	require.ErrorIs(t, err, mterr.ErrInvalidProof)
+}
+
+func TestProofBinding(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, scheme := range []Scheme{{}, {DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962}} {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for _, n := range []int{4, 5, 7} {
+			files := benchmarkLeaves(n)
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+
+			proofs, err := merkleTree.GenerateMerkleProof(2)
+			require.NoError(t, err)
+
+			// The proof for index 2 can not be replayed for index 3, neither with file 2 nor with file 3
+			for _, file := range [][]byte{files[2], files[3]} {
+				isVerified, err := VerifyProof(rootHash, file, 3, n, proofs, opts...)
+				if err == nil {
+					require.False(t, isVerified)
+				} else {
+					require.ErrorIs(t, err, mterr.ErrInvalidProof)
+				}
+
+				isVerified, err = merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(file), 3, proofs)
+				if err == nil {
+					require.False(t, isVerified)
+				} else {
+					require.ErrorIs(t, err, mterr.ErrInvalidProof)
+				}
+			}
+
+			// Forged node indices pretending the proof belongs to index 3 do not change the outcome
+			forged := make([]*TreeNode, len(proofs))
+			for idx, proof := range proofs {
+				forged[idx] = &TreeNode{Hash: proof.Hash, LeftIdx: proof.RightIdx, RightIdx: proof.LeftIdx}
+			}
+			isVerified, err := merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(files[2]), 2, forged)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+			isVerified, err = merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(files[3]), 3, forged)
+			if err == nil {
+				require.False(t, isVerified)
+			}
+
+			// Proofs of the wrong length are rejected
+			for _, tampered := range [][]*TreeNode{proofs[:len(proofs)-1], append(append([]*TreeNode(nil), proofs...), proofs[0])} {
+				_, err = VerifyProof(rootHash, files[2], 2, n, tampered, opts...)
+				require.ErrorIs(t, err, mterr.ErrInvalidProof)
+				_, err = merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(files[2]), 2, tampered)
+				require.ErrorIs(t, err, mterr.ErrInvalidProof)
+			}
+
+			_, err = merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(files[2]), n, proofs)
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+		}
+	}
+}
+
+func TestHashers(t *testing.T) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"),
+		[]byte("E"),
+	}
+
+	rootHashes := make(map[string]bool)
+	for _, name := range HashAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			h, err := HasherByName(name)
+			require.NoError(t, err)
+			require.Equal(t, name, h.Name())
+			require.Len(t, h.Sum([]byte("A")), h.Size())
+
+			merkleTree, err := BuildMerkleTree(files, WithHasher(h))
+			require.NoError(t, err)
+			require.Equal(t, Scheme{HashAlgorithm: name, Version: VersionHex, Shape: ShapeMidpoint}, merkleTree.Scheme())
+
+			rootHash := merkleTree.GetMerkleRoot().Hash
+			require.Len(t, rootHash, h.Size())
+			require.False(t, rootHashes[EncodeHash(rootHash)], "hash algorithms must produce distinct merkle roots")
+			rootHashes[EncodeHash(rootHash)] = true
+
+			for idx, file := range files {
+				merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+
+				isVerified, err := merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(file), idx, merkleProofs)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+
+				isVerified, err = VerifyProof(rootHash, file, idx, len(files), merkleProofs, WithHasher(h))
+				require.NoError(t, err)
+				require.True(t, isVerified)
+			}
+		})
+	}
+
+	// The default hasher is SHA-256, which keeps the roots of existing trees unchanged
+	merkleTree, err := BuildMerkleTree(files)
+	require.NoError(t, err)
+	require.Equal(t, SHA256, merkleTree.Hasher().Name())
+
+	h, err := HasherByName("")
+	require.NoError(t, err)
+	require.Equal(t, SHA256, h.Name())
+
+	_, err = HasherByName("md5")
+	require.ErrorIs(t, err, mterr.ErrUnknownHashAlgorithm)
+
+	// A proof must not verify under a different hash algorithm
+	merkleProofs, err := merkleTree.GenerateMerkleProof(1)
+	require.NoError(t, err)
+	sha3, err := HasherByName(SHA3_256)
+	require.NoError(t, err)
+	isVerified, err := VerifyProof(merkleTree.GetMerkleRoot().Hash, files[1], 1, len(files), merkleProofs, WithHasher(sha3))
+	require.NoError(t, err)
+	require.False(t, isVerified)
+}
+
+func TestDomainSeparation(t *testing.T) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"),
+	}
+
+	legacyTree, err := BuildMerkleTree(files)
+	require.NoError(t, err)
+	merkleTree, err := BuildMerkleTree(files, WithDomainSeparation(true))
+	require.NoError(t, err)
+	require.NotEqual(t, legacyTree.GetMerkleRoot().Hash, merkleTree.GetMerkleRoot().Hash)
+	require.True(t, merkleTree.Scheme().DomainSeparation)
+
+	for idx, file := range files {
+		merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+		require.NoError(t, err)
+
+		isVerified, err := merkleTree.VerifyMerkleProof(merkleTree.GetMerkleRoot().Hash, merkleTree.LeafHash(file), idx, merkleProofs)
+		require.NoError(t, err)
+		require.True(t, isVerified)
+
+		isVerified, err = VerifyProof(merkleTree.GetMerkleRoot().Hash, file, idx, len(files), merkleProofs, WithDomainSeparation(true))
+		require.NoError(t, err)
+		require.True(t, isVerified)
+
+		// Roots built without domain separation remain verifiable under the legacy scheme only
+		legacyProofs, err := legacyTree.GenerateMerkleProof(idx)
+		require.NoError(t, err)
+		isVerified, err = VerifyProof(legacyTree.GetMerkleRoot().Hash, file, idx, len(files), legacyProofs)
+		require.NoError(t, err)
+		require.True(t, isVerified)
+		isVerified, err = VerifyProof(legacyTree.GetMerkleRoot().Hash, file, idx, len(files), legacyProofs, WithDomainSeparation(true))
+		require.NoError(t, err)
+		require.False(t, isVerified)
+	}
+
+	// Second-preimage attack: the preimages of the interior nodes presented as leaves
+	forged := func(tree *MerkleTree) [][]byte {
+		root := tree.GetMerkleRoot()
+		return [][]byte{
+			[]byte(EncodeHash(root.Left.Left.Hash) + EncodeHash(root.Left.Right.Hash)),
+			[]byte(EncodeHash(root.Right.Left.Hash) + EncodeHash(root.Right.Right.Hash)),
+		}
+	}
+
+	forgedTree, err := BuildMerkleTree(forged(legacyTree))
+	require.NoError(t, err)
+	require.Equal(t, legacyTree.GetMerkleRoot().Hash, forgedTree.GetMerkleRoot().Hash)
+
+	forgedTree, err = BuildMerkleTree(forged(merkleTree), WithDomainSeparation(true))
+	require.NoError(t, err)
+	require.NotEqual(t, merkleTree.GetMerkleRoot().Hash, forgedTree.GetMerkleRoot().Hash)
+}
+
+func TestVersions(t *testing.T) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"),
+	}
+
+	sha256 := DefaultHasher()
+	leaves := [][]byte{sha256.Sum(files[0]), sha256.Sum(files[1]), sha256.Sum(files[2])}
+
+	// Binary trees hash the raw digests of the children
+	concat := func(left, right []byte) []byte {
+		return sha256.Sum(append(append([]byte{}, left...), right...))
+	}
+	merkleTree, err := BuildMerkleTree(files, WithVersion(VersionBinary))
+	require.NoError(t, err)
+	require.Equal(t, concat(concat(leaves[0], leaves[1]), leaves[2]), merkleTree.GetMerkleRoot().Hash)
+
+	// Hexadecimal trees hash the hexadecimal encoding of the child digests
+	hexConcat := func(left, right []byte) []byte {
+		return sha256.Sum([]byte(EncodeHash(left) + EncodeHash(right)))
+	}
+	legacyTree, err := BuildMerkleTree(files)
+	require.NoError(t, err)
+	require.Equal(t, VersionHex, legacyTree.Scheme().Version)
+	require.Equal(t, hexConcat(hexConcat(leaves[0], leaves[1]), leaves[2]), legacyTree.GetMerkleRoot().Hash)
+
+	for idx, file := range files {
+		merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+		require.NoError(t, err)
+		isVerified, err := VerifyProof(merkleTree.GetMerkleRoot().Hash, file, idx, len(files), merkleProofs, WithVersion(VersionBinary))
+		require.NoError(t, err)
+		require.True(t, isVerified)
+
+		// Roots of existing trees stay verifiable in the compatibility mode
+		legacyProofs, err := legacyTree.GenerateMerkleProof(idx)
+		require.NoError(t, err)
+		isVerified, err = VerifyProof(legacyTree.GetMerkleRoot().Hash, file, idx, len(files), legacyProofs, WithVersion(VersionHex))
+		require.NoError(t, err)
+		require.True(t, isVerified)
+	}
+
+	// The zero scheme describes the original hexadecimal tree
+	require.True(t, Scheme{}.Equal(legacyTree.Scheme()))
+	require.Equal(t, legacyTree.Scheme(), Scheme{}.Normalize())
+	require.Equal(t, Scheme{ChunkSize: 4}.Normalize(), Scheme{HashAlgorithm: SHA256, Version: VersionHex, Shape: ShapeMidpoint, ChunkSize: 4, Chunking: ChunkingFixed})
+	_, err = Scheme{Version: 3}.Options()
+	require.ErrorIs(t, err, mterr.ErrUnknownSchemeVersion)
+}
+
+func TestLayouts(t *testing.T) {
+	schemes := []Scheme{{}, {DomainSeparation: true, Version: VersionBinary}}
+	for _, scheme := range schemes {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for n := 1; n <= 26; n++ {
+			files := benchmarkLeaves(n)
+			pointerTree, err := BuildMerkleTree(files, append(opts, WithLayout(LayoutPointer))...)
+			require.NoError(t, err)
+			flatTree, err := BuildMerkleTree(files, append(opts, WithLayout(LayoutFlat))...)
+			require.NoError(t, err)
+
+			// Both layouts compute the same root and the same proofs
+			rootHash := pointerTree.GetMerkleRoot().Hash
+			require.Equal(t, rootHash, flatTree.GetMerkleRoot().Hash)
+			require.Equal(t, n, flatTree.LeafCount())
+			require.Len(t, flatTree.flat.levels[len(flatTree.flat.levels)-1], (2*n-(1<<(len(flatTree.flat.levels)-1)))*flatTree.flat.size)
+
+			for idx, file := range files {
+				pointerProofs, err := pointerTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				flatProofs, err := flatTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				require.Equal(t, len(pointerProofs), len(flatProofs))
+				for i := range pointerProofs {
+					require.Equal(t, pointerProofs[i].Hash, flatProofs[i].Hash)
+					require.Equal(t, pointerProofs[i].LeftIdx, flatProofs[i].LeftIdx)
+					require.Equal(t, pointerProofs[i].RightIdx, flatProofs[i].RightIdx)
+				}
+
+				isVerified, err := flatTree.VerifyMerkleProof(rootHash, flatTree.LeafHash(file), idx, flatProofs)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+
+				isVerified, err = VerifyProof(rootHash, file, idx, n, flatProofs, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+			}
+
+			_, err = flatTree.GenerateMerkleProof(n)
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+		}
+	}
+}
+
+func TestShapes(t *testing.T) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"),
+	}
+	sha256 := DefaultHasher()
+	leaves := make([][]byte, len(files))
+	for idx, file := range files {
+		leaves[idx] = sha256.Sum(file)
+	}
+	concat := func(left, right []byte) []byte {
+		return sha256.Sum(append(append([]byte{}, left...), right...))
+	}
+
+	// The midpoint split puts three of the five leaves into the left subtree, the RFC 6962 split four
+	midpointTree, err := BuildMerkleTree(files, WithVersion(VersionBinary))
+	require.NoError(t, err)
+	require.Equal(t, concat(concat(concat(leaves[0], leaves[1]), leaves[2]), concat(leaves[3], leaves[4])), midpointTree.GetMerkleRoot().Hash)
+
+	rfc6962Tree, err := BuildMerkleTree(files, WithVersion(VersionBinary), WithShape(ShapeRFC6962))
+	require.NoError(t, err)
+	require.Equal(t, concat(concat(concat(leaves[0], leaves[1]), concat(leaves[2], leaves[3])), leaves[4]), rfc6962Tree.GetMerkleRoot().Hash)
+	require.Equal(t, ShapeRFC6962, rfc6962Tree.Scheme().Shape)
+
+	// Bitcoin duplicates the odd node of every level: E is paired with itself, and so is their parent
+	bitcoinTree, err := BuildMerkleTree(files, WithVersion(VersionBinary), WithShape(ShapeBitcoin))
+	require.NoError(t, err)
+	ee := concat(leaves[4], leaves[4])
+	require.Equal(t, concat(concat(concat(leaves[0], leaves[1]), concat(leaves[2], leaves[3])), concat(ee, ee)), bitcoinTree.GetMerkleRoot().Hash)
+	require.Equal(t, ShapeBitcoin, bitcoinTree.Scheme().Shape)
+
+	// Proofs of one shape do not verify under the other
+	merkleProofs, err := rfc6962Tree.GenerateMerkleProof(4)
+	require.NoError(t, err)
+	isVerified, err := VerifyProof(rfc6962Tree.GetMerkleRoot().Hash, files[4], 4, len(files), merkleProofs, WithVersion(VersionBinary), WithShape(ShapeRFC6962))
+	require.NoError(t, err)
+	require.True(t, isVerified)
+	_, err = VerifyProof(rfc6962Tree.GetMerkleRoot().Hash, files[4], 4, len(files), merkleProofs, WithVersion(VersionBinary))
+	require.ErrorIs(t, err, mterr.ErrInvalidProof)
+
+	// The flat layout relies on the midpoint split
+	_, err = BuildMerkleTree(files, WithShape(ShapeRFC6962), WithLayout(LayoutFlat))
+	require.ErrorIs(t, err, mterr.ErrUnsupportedLayout)
+	_, err = Scheme{Shape: 99}.Options()
+	require.ErrorIs(t, err, mterr.ErrUnknownTreeShape)
+
+	// Every shape can be selected by its name
+	for _, name := range ShapeNames() {
+		shape, err := ShapeByName(name)
+		require.NoError(t, err)
+		_, err = Scheme{Shape: shape}.Options()
+		require.NoError(t, err)
+	}
+	shape, err := ShapeByName("midpoint")
+	require.NoError(t, err)
+	require.Equal(t, ShapeMidpoint, shape)
+	_, err = ShapeByName("")
+	require.ErrorIs(t, err, mterr.ErrUnknownTreeShape)
+}
+
+func TestBitcoinShape(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, scheme := range []Scheme{
+		{Version: VersionBinary, Shape: ShapeBitcoin},
+		{HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionHex, Shape: ShapeBitcoin},
+	} {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+		cfg := newConfig(opts)
+
+		files := benchmarkLeaves(40)
+		for n := 1; n <= len(files); n++ {
+			// Reference: hash the levels pairwise, pairing the last node of odd levels with itself
+			level := make([][]byte, n)
+			for idx, file := range files[:n] {
+				level[idx] = cfg.hashFile(file)
+			}
+			for len(level) > 1 {
+				if len(level)%2 == 1 {
+					level = append(level, level[len(level)-1])
+				}
+				next := make([][]byte, len(level)/2)
+				for idx := range next {
+					next[idx] = cfg.hashNode(level[2*idx], level[2*idx+1])
+				}
+				level = next
+			}
+
+			merkleTree, err := BuildMerkleTree(files[:n], opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+			require.Equal(t, level[0], rootHash, "n %d", n)
+
+			// The streaming builder yields the same root with and without the number of files
+			for _, leafCount := range []int{n, 0} {
+				builder, err := NewBuilder(leafCount, opts...)
+				require.NoError(t, err)
+				for _, file := range files[:n] {
+					require.NoError(t, builder.Add(bytes.NewReader(file)))
+				}
+				builderRoot, err := builder.Finish()
+				require.NoError(t, err)
+				require.Equal(t, rootHash, builderRoot)
+			}
+
+			for idx, file := range files[:n] {
+				proofs, err := merkleTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				isVerified, err := VerifyProof(rootHash, file, idx, n, proofs, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+				isVerified, err = merkleTree.VerifyMerkleProof(rootHash, merkleTree.LeafHash(file), idx, proofs)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+			}
+
+			multiProofs, err := merkleTree.GenerateMultiProof([]int{0, n / 2, n - 1})
+			require.NoError(t, err)
+			isVerified, err := VerifyMultiProof(rootHash, []int{0, n / 2, n - 1}, [][]byte{files[0], files[n/2], files[n-1]}, n, multiProofs, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+			rangeProofs, err := merkleTree.GenerateRangeProof(n/2, n-1)
+			require.NoError(t, err)
+			isVerified, err = VerifyRangeProof(rootHash, n/2, files[n/2:n], n, rangeProofs, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+
+			// Appending and updating leaves yields the roots of the rebuilt trees
+			if n > 1 {
+				grown, err := BuildMerkleTree(files[:n-1], opts...)
+				require.NoError(t, err)
+				require.NoError(t, grown.Append(files[n-1]))
+				require.Equal(t, rootHash, grown.GetMerkleRoot().Hash)
+				require.NoError(t, grown.UpdateLeaf(n/2, files[0]))
+				updated := append(append(append([][]byte{}, files[:n/2]...), files[0]), files[n/2+1:n]...)
+				rebuilt, err := BuildMerkleTree(updated, opts...)
+				require.NoError(t, err)
+				require.Equal(t, rebuilt.GetMerkleRoot().Hash, grown.GetMerkleRoot().Hash)
+			}
+
+			data, err := merkleTree.MarshalBinary()
+			require.NoError(t, err)
+			decoded, err := ReadMerkleTree(bytes.NewReader(data))
+			require.NoError(t, err)
+			require.Equal(t, rootHash, decoded.GetMerkleRoot().Hash)
+			require.True(t, scheme.Equal(decoded.Scheme()))
+		}
+	}
+
+	// Like in Bitcoin, duplicating the last leaf keeps the root hash, but the recorded number of leaves tells them apart
+	files := [][]byte{[]byte("A"), []byte("B"), []byte("C")}
+	merkleTree, err := BuildMerkleTree(files, WithShape(ShapeBitcoin))
+	require.NoError(t, err)
+	duplicated, err := BuildMerkleTree(append(files, files[2]), WithShape(ShapeBitcoin))
+	require.NoError(t, err)
+	require.Equal(t, merkleTree.GetMerkleRoot().Hash, duplicated.GetMerkleRoot().Hash)
+
+	proofs, err := duplicated.GenerateMerkleProof(3)
+	require.NoError(t, err)
+	_, err = VerifyProof(merkleTree.GetMerkleRoot().Hash, files[2], 3, len(files), proofs, WithShape(ShapeBitcoin))
+	require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+
+	// Bitcoin trees neither have a flat layout nor consistency proofs
+	_, err = BuildMerkleTree(files, WithShape(ShapeBitcoin), WithLayout(LayoutFlat))
+	require.ErrorIs(t, err, mterr.ErrUnsupportedLayout)
+	_, err = duplicated.GenerateConsistencyProof(3, 4)
+	require.ErrorIs(t, err, mterr.ErrUnsupportedShape)
+}
+
+func TestAppend(t *testing.T) {
+	schemes := []Scheme{
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+		{Shape: ShapeBitcoin},
+	}
+
+	for _, scheme := range schemes {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		files := benchmarkLeaves(26)
+		merkleTree, err := BuildMerkleTree(files[:1], opts...)
+		require.NoError(t, err)
+
+		for n := 2; n <= len(files); n++ {
+			oldRootHash := merkleTree.GetMerkleRoot().Hash
+			oldProofs, err := merkleTree.GenerateMerkleProof(0)
+			require.NoError(t, err)
+
+			require.NoError(t, merkleTree.Append(files[n-1]))
+			require.Equal(t, n, merkleTree.LeafCount())
+
+			// Appending yields the same tree as building it over all files at once
+			expectedTree, err := BuildMerkleTree(files[:n], opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+			require.Equal(t, expectedTree.GetMerkleRoot().Hash, rootHash)
+
+			for idx := 0; idx < n; idx++ {
+				merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				isVerified, err := VerifyProof(rootHash, files[idx], idx, n, merkleProofs, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+			}
+
+			// Proofs handed out before the append remain valid for the old root
+			isVerified, err := VerifyProof(oldRootHash, files[0], 0, n-1, oldProofs, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+		}
+	}
+
+	// Appending several files at once yields the same tree as appending them one by one
+	files := benchmarkLeaves(26)
+	for _, opts := range [][]Option{{WithShape(ShapeRFC6962)}, {WithShape(ShapeMountainRange)}, {WithShape(ShapeBitcoin)}} {
+		expectedTree, err := BuildMerkleTree(files, opts...)
+		require.NoError(t, err)
+
+		for _, batch := range []int{1, 2, 7, 25} {
+			merkleTree, err := BuildMerkleTree(files[:1], opts...)
+			require.NoError(t, err)
+			for n := 1; n < len(files); n += batch {
+				require.NoError(t, merkleTree.Append(files[n:min(n+batch, len(files))]...))
+			}
+			require.Equal(t, len(files), merkleTree.LeafCount())
+			require.Equal(t, expectedTree.GetMerkleRoot().Hash, merkleTree.GetMerkleRoot().Hash)
+		}
+
+		merkleTree, err := BuildMerkleTree(files, opts...)
+		require.NoError(t, err)
+		require.NoError(t, merkleTree.Append())
+		require.Equal(t, expectedTree.GetMerkleRoot().Hash, merkleTree.GetMerkleRoot().Hash)
+	}
+
+	// Trees with the midpoint shape, including every tree in the flat layout, cannot be appended to
+	for _, opts := range [][]Option{nil, {WithLayout(LayoutFlat)}} {
+		merkleTree, err := BuildMerkleTree(files[:3], opts...)
+		require.NoError(t, err)
+		rootHash := merkleTree.GetMerkleRoot().Hash
+		require.ErrorIs(t, merkleTree.Append(files[3]), mterr.ErrUnsupportedShape)
+		require.ErrorIs(t, merkleTree.Append(), mterr.ErrUnsupportedShape)
+		require.Equal(t, 3, merkleTree.LeafCount())
+		require.Equal(t, rootHash, merkleTree.GetMerkleRoot().Hash)
+	}
+}
+
+func TestConsistencyProof(t *testing.T) {
+	scheme := Scheme{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962}
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+
+	files := benchmarkLeaves(26)
+	merkleTree, err := BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+
+	rootHashes := make([][]byte, len(files)+1)
+	for n := 1; n <= len(files); n++ {
+		tree, err := BuildMerkleTree(files[:n], opts...)
+		require.NoError(t, err)
+		rootHashes[n] = tree.GetMerkleRoot().Hash
+	}
+
+	for newSize := 1; newSize <= len(files); newSize++ {
+		for oldSize := 1; oldSize <= newSize; oldSize++ {
+			consistencyProofs, err := merkleTree.GenerateConsistencyProof(oldSize, newSize)
+			require.NoError(t, err)
+
+			isVerified, err := VerifyConsistencyProof(rootHashes[oldSize], rootHashes[newSize], oldSize, newSize, consistencyProofs, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified, "old size %d, new size %d", oldSize, newSize)
+
+			// A rewritten history is detected
+			if oldSize > 1 {
+				isVerified, err = VerifyConsistencyProof(rootHashes[oldSize-1], rootHashes[newSize], oldSize, newSize, consistencyProofs, opts...)
+				require.False(t, isVerified && err == nil)
+			}
+			if oldSize < newSize {
+				isVerified, err = VerifyConsistencyProof(rootHashes[oldSize], rootHashes[newSize-1], oldSize, newSize, consistencyProofs, opts...)
+				require.False(t, isVerified && err == nil)
+			}
+		}
+	}
+
+	_, err = merkleTree.GenerateConsistencyProof(0, 3)
+	require.ErrorIs(t, err, mterr.ErrInvalidTreeSize)
+	_, err = merkleTree.GenerateConsistencyProof(3, len(files)+1)
+	require.ErrorIs(t, err, mterr.ErrInvalidTreeSize)
+
+	// Midpoint trees rewrite their nodes when growing
+	midpointTree, err := BuildMerkleTree(files)
+	require.NoError(t, err)
+	_, err = midpointTree.GenerateConsistencyProof(3, 5)
+	require.ErrorIs(t, err, mterr.ErrUnsupportedShape)
+	_, err = VerifyConsistencyProof(rootHashes[3], rootHashes[5], 3, 5, nil)
+	require.ErrorIs(t, err, mterr.ErrUnsupportedShape)
+}
+
+func TestMountainRange(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, scheme := range []Scheme{
+		{Shape: ShapeMountainRange},
+		{HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionBinary, Shape: ShapeMountainRange},
+	} {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+		rfcScheme := scheme
+		rfcScheme.Shape = ShapeRFC6962
+		rfcOpts, err := rfcScheme.Options()
+		require.NoError(t, err)
+
+		files := benchmarkLeaves(40)
+		mmr, err := BuildMerkleTree(files[:1], opts...)
+		require.NoError(t, err)
+		require.NotNil(t, mmr.mmr)
+
+		for n := 1; n <= len(files); n++ {
+			if n > 1 {
+				// Appending keeps all nodes of the mountains and only adds new ones behind them
+				nodes := bytes.Clone(mmr.mmr.nodes)
+				require.NoError(t, mmr.Append(files[n-1]))
+				require.Equal(t, nodes, mmr.mmr.nodes[:len(nodes)])
+			}
+			require.Equal(t, n, mmr.LeafCount())
+			require.True(t, scheme.Equal(mmr.Scheme()))
+
+			built, err := BuildMerkleTree(files[:n], opts...)
+			require.NoError(t, err)
+			require.Equal(t, string(built.mmr.nodes)+string(built.mmr.bags), string(mmr.mmr.nodes)+string(mmr.mmr.bags))
+
+			// The bagged peaks yield the root hash of the RFC 6962 tree
+			rfcTree, err := BuildMerkleTree(files[:n], rfcOpts...)
+			require.NoError(t, err)
+			rootHash := mmr.GetMerkleRoot().Hash
+			require.Equal(t, rfcTree.GetMerkleRoot().Hash, rootHash)
+
+			peaks, err := mmr.Peaks()
+			require.NoError(t, err)
+			require.Len(t, peaks, bits.OnesCount(uint(n)))
+			rfcPeaks, err := rfcTree.Peaks()
+			require.NoError(t, err)
+			require.Equal(t, rfcPeaks, peaks)
+			bagged, err := BagPeaks(peaks, opts...)
+			require.NoError(t, err)
+			require.Equal(t, rootHash, bagged)
+
+			for idx, file := range files[:n] {
+				proofs, err := mmr.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				rfcProofs, err := rfcTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				require.Equal(t, len(rfcProofs), len(proofs))
+				for i := range proofs {
+					require.Equal(t, rfcProofs[i].Hash, proofs[i].Hash)
+				}
+
+				isVerified, err := VerifyProof(rootHash, file, idx, n, proofs, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+				isVerified, err = mmr.VerifyMerkleProof(rootHash, mmr.LeafHash(file), idx, proofs)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+			}
+
+			// Consistency proofs show that the mountain range only grew by appending
+			for oldSize := 1; oldSize <= n; oldSize++ {
+				oldTree, err := BuildMerkleTree(files[:oldSize], opts...)
+				require.NoError(t, err)
+				proofs, err := mmr.GenerateConsistencyProof(oldSize, n)
+				require.NoError(t, err)
+				isVerified, err := VerifyConsistencyProof(oldTree.GetMerkleRoot().Hash, rootHash, oldSize, n, proofs, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+			}
+
+			// Updated leaves yield the same root as rebuilding the tree
+			updated := append([][]byte(nil), files[:n]...)
+			updated[n/2] = []byte("updated")
+			updatedTree, err := BuildMerkleTree(updated, opts...)
+			require.NoError(t, err)
+			require.NoError(t, built.UpdateLeaf(n/2, updated[n/2]))
+			require.Equal(t, updatedTree.GetMerkleRoot().Hash, built.GetMerkleRoot().Hash)
+			require.Equal(t, string(updatedTree.mmr.nodes)+string(updatedTree.mmr.bags), string(built.mmr.nodes)+string(built.mmr.bags))
+
+			// Mountain ranges round trip through the binary encoding
+			data, err := mmr.MarshalBinary()
+			require.NoError(t, err)
+			decoded, err := ReadMerkleTree(bytes.NewReader(data))
+			require.NoError(t, err)
+			require.Equal(t, string(mmr.mmr.nodes)+string(mmr.mmr.bags), string(decoded.mmr.nodes)+string(decoded.mmr.bags))
+		}
+	}
+
+	merkleTree, err := BuildMerkleTree(benchmarkLeaves(3))
+	require.NoError(t, err)
+	_, err = merkleTree.Peaks()
+	require.ErrorIs(t, err, mterr.ErrUnsupportedShape)
+
+	_, err = BagPeaks(nil)
+	require.ErrorIs(t, err, mterr.ErrEmptyRoot)
+
+	_, err = BuildMerkleTree(benchmarkLeaves(3), WithShape(ShapeMountainRange), WithLayout(LayoutFlat))
+	require.ErrorIs(t, err, mterr.ErrUnsupportedLayout)
+}
+
+func TestUpdateLeaf(t *testing.T) {
+	schemes := []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+	}
+	layouts := []Layout{LayoutPointer, LayoutFlat}
+
+	for _, scheme := range schemes {
+		for _, layout := range layouts {
+			if layout == LayoutFlat && scheme.Shape == ShapeRFC6962 {
+				continue
+			}
+
+			opts, err := scheme.Options()
+			require.NoError(t, err)
+			opts = append(opts, WithLayout(layout))
+
+			for n := 1; n <= 26; n++ {
+				files := benchmarkLeaves(n)
+				merkleTree, err := BuildMerkleTree(files, opts...)
+				require.NoError(t, err)
+
+				for idx := 0; idx < n; idx++ {
+					oldRootHash := merkleTree.GetMerkleRoot().Hash
+					oldLeafHash := merkleTree.LeafHash(files[idx])
+					oldProofs, err := merkleTree.GenerateMerkleProof(idx)
+					require.NoError(t, err)
+
+					files[idx] = append([]byte("updated"), files[idx]...)
+					require.NoError(t, merkleTree.UpdateLeaf(idx, files[idx]))
+
+					// Updating yields the same tree as building it over the updated files
+					expectedTree, err := BuildMerkleTree(files, opts...)
+					require.NoError(t, err)
+					rootHash := merkleTree.GetMerkleRoot().Hash
+					require.Equal(t, expectedTree.GetMerkleRoot().Hash, rootHash)
+
+					merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+					require.NoError(t, err)
+					isVerified, err := VerifyUpdateProof(oldRootHash, rootHash, oldLeafHash, files[idx], idx, n, merkleProofs, opts...)
+					require.NoError(t, err)
+					require.True(t, isVerified)
+
+					// Proofs handed out before the update remain valid for the old root
+					isVerified, err = VerifyUpdateProof(oldRootHash, rootHash, oldLeafHash, files[idx], idx, n, oldProofs, opts...)
+					require.NoError(t, err)
+					require.True(t, isVerified)
+				}
+
+				// The update proof does not hold for a different replaced leaf
+				if n > 1 {
+					merkleProofs, err := merkleTree.GenerateMerkleProof(0)
+					require.NoError(t, err)
+					rootHash := merkleTree.GetMerkleRoot().Hash
+					isVerified, err := VerifyUpdateProof(rootHash, rootHash, merkleTree.LeafHash(files[1]), files[0], 0, n, merkleProofs, opts...)
+					require.NoError(t, err)
+					require.False(t, isVerified)
+				}
+
+				require.ErrorIs(t, merkleTree.UpdateLeaf(n, nil), mterr.ErrIndexOutOfBound)
+			}
+		}
+	}
+}
+
+func TestMultiProof(t *testing.T) {
+	schemes := []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+	}
+
+	for _, scheme := range schemes {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for n := 1; n <= 20; n++ {
+			files := benchmarkLeaves(n)
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+
+			for i := 0; i < n; i++ {
+				for j := i; j < n; j++ {
+					// Duplicate and unordered indices are accepted
+					leafIdxs := []int{j, i, j}
+					leaves := [][]byte{files[j], files[i], files[j]}
+					multiProofs, err := merkleTree.GenerateMultiProof(leafIdxs)
+					require.NoError(t, err)
+
+					isVerified, err := VerifyMultiProof(rootHash, leafIdxs, leaves, n, multiProofs, opts...)
+					require.NoError(t, err)
+					require.True(t, isVerified)
+
+					// Shared siblings are only included once, and the subtrees holding the other leaf not at all
+					proofs, err := merkleTree.GenerateMerkleProof(i)
+					require.NoError(t, err)
+					if i == j {
+						require.Len(t, multiProofs, len(proofs))
+					} else {
+						otherProofs, err := merkleTree.GenerateMerkleProof(j)
+						require.NoError(t, err)
+						require.LessOrEqual(t, len(multiProofs), len(proofs)+len(otherProofs)-2)
+
+						// Claiming the file of the other index is detected
+						leaves[1] = files[j]
+						isVerified, err = VerifyMultiProof(rootHash, leafIdxs, leaves, n, multiProofs, opts...)
+						require.NoError(t, err)
+						require.False(t, isVerified)
+					}
+				}
+			}
+
+			// Proving all leaves needs no proof nodes at all
+			leafIdxs := make([]int, n)
+			for idx := range leafIdxs {
+				leafIdxs[idx] = idx
+			}
+			multiProofs, err := merkleTree.GenerateMultiProof(leafIdxs)
+			require.NoError(t, err)
+			require.Empty(t, multiProofs)
+			isVerified, err := VerifyMultiProof(rootHash, leafIdxs, files, n, multiProofs, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+
+			_, err = merkleTree.GenerateMultiProof([]int{0, n})
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+			_, err = merkleTree.GenerateMultiProof(nil)
+			require.ErrorIs(t, err, mterr.ErrEmptyIndices)
+		}
+	}
+
+	// The number of proof nodes has to match the indices
+	files := benchmarkLeaves(8)
+	merkleTree, err := BuildMerkleTree(files)
+	require.NoError(t, err)
+	multiProofs, err := merkleTree.GenerateMultiProof([]int{0, 1})
+	require.NoError(t, err)
+	require.Len(t, multiProofs, 2)
+	_, err = VerifyMultiProof(merkleTree.GetMerkleRoot().Hash, []int{0, 1}, files[:2], 8, append(multiProofs, multiProofs[0]))
+	require.ErrorIs(t, err, mterr.ErrInvalidProof)
+	_, err = VerifyMultiProof(merkleTree.GetMerkleRoot().Hash, []int{0, 1}, files[:2], 8, multiProofs[:1])
+	require.ErrorIs(t, err, mterr.ErrInvalidProof)
+}
+
+func TestRangeProof(t *testing.T) {
+	schemes := []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+	}
+
+	for _, scheme := range schemes {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for n := 1; n <= 20; n++ {
+			files := benchmarkLeaves(n)
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+
+			for from := 0; from < n; from++ {
+				for to := from; to < n; to++ {
+					rangeProofs, err := merkleTree.GenerateRangeProof(from, to)
+					require.NoError(t, err)
+
+					isVerified, err := VerifyRangeProof(rootHash, from, files[from:to+1], n, rangeProofs, opts...)
+					require.NoError(t, err)
+					require.True(t, isVerified)
+
+					// Only the two boundary paths are needed
+					fromProofs, err := merkleTree.GenerateMerkleProof(from)
+					require.NoError(t, err)
+					toProofs, err := merkleTree.GenerateMerkleProof(to)
+					require.NoError(t, err)
+					require.LessOrEqual(t, len(rangeProofs), len(fromProofs)+len(toProofs))
+
+					// The files have to be the ones at the claimed indices
+					if to+1 < n {
+						isVerified, err = VerifyRangeProof(rootHash, from, files[from+1:to+2], n, rangeProofs, opts...)
+						require.False(t, isVerified && err == nil)
+					}
+				}
+			}
+
+			_, err = merkleTree.GenerateRangeProof(0, n)
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+			_, err = merkleTree.GenerateRangeProof(1, 0)
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+		}
+	}
+}
+
+func TestSparseMerkleTree(t *testing.T) {
+	for _, hashAlgo := range []string{SHA256, BLAKE2b256} {
+		opts, err := Scheme{HashAlgorithm: hashAlgo}.Options()
+		require.NoError(t, err)
+
+		names := make([]string, 50)
+		for idx := range names {
+			names[idx] = fmt.Sprintf("dir%d/file%d.txt", idx%3, idx)
+		}
+		files := benchmarkLeaves(len(names))
+
+		smt, err := BuildSparseMerkleTree(names, files, opts...)
+		require.NoError(t, err)
+		rootHash := smt.RootHash()
+
+		for idx, name := range names {
+			proof := smt.GenerateProof(name)
+			require.Equal(t, SparseKey(name, opts...), proof.LeafKey)
+
+			isVerified, err := VerifySparseInclusion(rootHash, name, files[idx], proof, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+
+			// Neither a different file nor an exclusion holds for a present name
+			isVerified, err = VerifySparseInclusion(rootHash, name, files[(idx+1)%len(files)], proof, opts...)
+			require.NoError(t, err)
+			require.False(t, isVerified)
+			isVerified, err = VerifySparseExclusion(rootHash, name, proof, opts...)
+			require.NoError(t, err)
+			require.False(t, isVerified)
+
+			// Tampering with a sibling breaks the proof
+			if len(proof.Siblings) > 0 {
+				proof.Siblings[0] = rootHash
+				isVerified, err = VerifySparseInclusion(rootHash, name, files[idx], proof, opts...)
+				require.NoError(t, err)
+				require.False(t, isVerified)
+			}
+		}
+
+		// Absent names end either at an empty subtree or at the leaf of another name
+		endsAtLeaf, endsAtEmpty := false, false
+		for idx := 0; idx < 200; idx++ {
+			name := fmt.Sprintf("absent%d", idx)
+			proof := smt.GenerateProof(name)
+			endsAtLeaf = endsAtLeaf || proof.LeafKey != nil
+			endsAtEmpty = endsAtEmpty || proof.LeafKey == nil
+
+			isVerified, err := VerifySparseExclusion(rootHash, name, proof, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+
+			// The proof of an absent name does not prove the absence of a present one
+			isVerified, err = VerifySparseExclusion(rootHash, names[0], proof, opts...)
+			require.False(t, isVerified && err == nil)
+		}
+		require.True(t, endsAtLeaf)
+		require.True(t, endsAtEmpty)
+
+		// Inserting files one by one into an empty tree results in the same root as building the tree at once,
+		// which also follows from the proofs of the absent names against the roots before every insertion
+		incremental := NewSparseMerkleTree(opts...)
+		for idx := 0; idx < len(names); idx++ {
+			oldRootHash, proof := incremental.RootHash(), incremental.GenerateProof(names[idx])
+			incremental.Put(names[idx], files[idx])
+
+			newRootHash, err := UpdateSparseRoot(oldRootHash, names[idx], files[idx], proof, opts...)
+			require.NoError(t, err)
+			require.Equal(t, incremental.RootHash(), newRootHash)
+
+			_, err = UpdateSparseRoot(newRootHash, names[idx], files[idx], proof, opts...)
+			require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+		}
+		require.Equal(t, rootHash, incremental.RootHash())
+
+		// The root after replacing a file follows from the proof of its presence
+		for _, idx := range []int{0, 7, 49} {
+			oldRootHash, proof := incremental.RootHash(), incremental.GenerateProof(names[idx])
+			incremental.Put(names[idx], []byte("replaced"))
+			newRootHash, err := UpdateSparseRoot(oldRootHash, names[idx], []byte("replaced"), proof, opts...)
+			require.NoError(t, err)
+			require.Equal(t, incremental.RootHash(), newRootHash)
+		}
+
+		// Replacing a file changes the root but keeps proofs handed out before intact
+		proof := smt.GenerateProof(names[7])
+		smt.Put(names[7], []byte("replaced"))
+		require.NotEqual(t, rootHash, smt.RootHash())
+		isVerified, err := VerifySparseInclusion(rootHash, names[7], files[7], proof, opts...)
+		require.NoError(t, err)
+		require.True(t, isVerified)
+		isVerified, err = VerifySparseInclusion(smt.RootHash(), names[7], []byte("replaced"), smt.GenerateProof(names[7]), opts...)
+		require.NoError(t, err)
+		require.True(t, isVerified)
+	}
+
+	_, err := BuildSparseMerkleTree([]string{"a", "a"}, [][]byte{[]byte("A"), []byte("B")})
+	require.ErrorIs(t, err, mterr.ErrDuplicateFileName)
+	_, err = BuildSparseMerkleTree([]string{"a"}, [][]byte{[]byte("A"), []byte("B")})
+	require.ErrorIs(t, err, mterr.ErrMissingFileNames)
+	_, err = BuildSparseMerkleTree(nil, nil)
+	require.ErrorIs(t, err, mterr.ErrEmptyFile)
+}
+
+// TestParallelBuild compares trees built by several workers with the serial build. Run it with
+// `go test -race` to check the concurrent build for data races.
+func TestContentDefinedChunking(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	const chunkSize = 256
+	file := make([]byte, 64*chunkSize)
+	rand.New(rand.NewSource(1)).Read(file)
+
+	scheme := Scheme{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962, ChunkSize: chunkSize, Chunking: ChunkingContentDefined}
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+
+	// Chunks cover the file and are at least a quarter and at most eight times the chunk size long
+	chunks := Chunks(file, opts...)
+	require.Greater(t, len(chunks), len(file)/(cdcMaxRatio*chunkSize))
+	var joined []byte
+	for idx, chunk := range chunks {
+		require.LessOrEqual(t, len(chunk), cdcMaxRatio*chunkSize)
+		if idx < len(chunks)-1 {
+			require.Greater(t, len(chunk), chunkSize/cdcMinRatio)
+		}
+		joined = append(joined, chunk...)
+	}
+	require.Equal(t, file, joined)
+
+	// Inserting bytes only changes the chunks around the edit
+	edited := append(append(append([]byte(nil), file[:len(file)/2]...), "inserted"...), file[len(file)/2:]...)
+	keys := make(map[string]bool)
+	for _, chunk := range chunks {
+		keys[ChunkKey(chunk)] = true
+	}
+	editedChunks := Chunks(edited, opts...)
+	shared := 0
+	for _, chunk := range editedChunks {
+		if keys[ChunkKey(chunk)] {
+			shared++
+		}
+	}
+	require.GreaterOrEqual(t, shared, len(editedChunks)-2)
+
+	// Every chunk verifies, the streaming builder yields the same root and shifted chunk boundaries fail
+	files := [][]byte{[]byte("A"), file, edited}
+	merkleTree, err := BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+	rootHash := merkleTree.GetMerkleRoot().Hash
+	require.True(t, scheme.Equal(merkleTree.Scheme()))
+
+	builder, err := NewBuilder(0, opts...)
+	require.NoError(t, err)
+	for _, f := range files {
+		require.NoError(t, builder.Add(bytes.NewReader(f)))
+	}
+	root, err := builder.Finish()
+	require.NoError(t, err)
+	require.Equal(t, rootHash, root)
+
+	cfg := newConfig(opts)
+	fileProofs, err := merkleTree.GenerateMerkleProof(1)
+	require.NoError(t, err)
+	for chunkIdx, chunk := range chunks {
+		chunkProofs, err := GenerateChunkProof(file, chunkIdx, opts...)
+		require.NoError(t, err)
+
+		isVerified, err := VerifyChunkProof(rootHash, chunk, chunkIdx, len(chunks), chunkProofs, 1, len(files), fileProofs, opts...)
+		require.NoError(t, err)
+		require.True(t, isVerified)
+
+		if chunkIdx < len(chunks)-1 {
+			require.False(t, cfg.isChunk(chunk[:len(chunk)-1], false))
+			require.False(t, cfg.isChunk(append(chunk, chunks[chunkIdx+1][0]), false))
+		}
+	}
+
+	// Fixed and content-defined chunking yield different trees
+	fixed := scheme
+	fixed.Chunking = ChunkingFixed
+	require.False(t, fixed.Equal(scheme))
+	require.NotEqual(t, LeafHash(file, opts...), LeafHash(file, WithChunkSize(chunkSize)))
+
+	_, err = Scheme{ChunkSize: chunkSize, Chunking: 3}.Options()
+	require.ErrorIs(t, err, mterr.ErrUnknownChunking)
+}
+
+func TestParallelBuild(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	schemes := []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+	}
+
+	for _, scheme := range schemes {
+		for _, layout := range []Layout{LayoutPointer, LayoutFlat} {
+			if layout == LayoutFlat && scheme.Shape == ShapeRFC6962 {
+				continue
+			}
+
+			opts, err := scheme.Options()
+			require.NoError(t, err)
+			opts = append(opts, WithLayout(layout))
+
+			for _, n := range []int{1, 2, parallelMinLeaves - 1, parallelMinLeaves, 3*parallelMinLeaves + 7, 20_000} {
+				files := benchmarkLeaves(n)
+				serial, err := BuildMerkleTree(files, append(opts, WithWorkers(1))...)
+				require.NoError(t, err)
+
+				for _, workers := range []int{2, 3, 8, 0} {
+					merkleTree, err := BuildMerkleTree(files, append(opts, WithWorkers(workers))...)
+					require.NoError(t, err)
+					require.Equal(t, serial.GetMerkleRoot().Hash, merkleTree.GetMerkleRoot().Hash)
+					require.Equal(t, serial.leaves(), merkleTree.leaves())
+
+					for _, idx := range []int{0, n / 3, n - 1} {
+						serialProofs, err := serial.GenerateMerkleProof(idx)
+						require.NoError(t, err)
+						merkleProofs, err := merkleTree.GenerateMerkleProof(idx)
+						require.NoError(t, err)
+						require.Equal(t, serialProofs, merkleProofs)
+					}
+				}
+			}
+		}
+	}
+
+	// Trees built concurrently by several callers do not interfere with each other
+	files := benchmarkLeaves(5 * parallelMinLeaves)
+	serial, err := BuildMerkleTree(files, WithWorkers(1))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	roots := make([][]byte, 8)
+	for idx := range roots {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			merkleTree, err := BuildMerkleTree(files, WithWorkers(4))
+			if err == nil {
+				roots[idx] = merkleTree.GetMerkleRoot().Hash
+			}
+		}(idx)
+	}
+	wg.Wait()
+	for _, root := range roots {
+		require.Equal(t, serial.GetMerkleRoot().Hash, root)
+	}
+}
+
+// sumOnlyHasher hides the streaming hash of the wrapped hasher, so the builder has to read files into memory.
+type sumOnlyHasher struct {
+	Hasher
+}
+
+func TestBuilder(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	schemes := []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary},
+		{HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+		{Shape: ShapeRFC6962},
+	}
+
+	for _, scheme := range schemes {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for n := 1; n <= 40; n++ {
+			files := benchmarkLeaves(n)
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+
+			builder, err := NewBuilder(n, opts...)
+			require.NoError(t, err)
+			for _, file := range files {
+				require.NoError(t, builder.Add(bytes.NewReader(file)))
+				require.LessOrEqual(t, len(builder.pending), bits.Len(uint(n)))
+			}
+			require.Equal(t, n, builder.LeafCount())
+
+			root, err := builder.Finish()
+			require.NoError(t, err)
+			require.Equal(t, rootHash, root)
+
+			// No more files than announced are accepted
+			require.ErrorIs(t, builder.Add(bytes.NewReader(files[0])), mterr.ErrLeafCountMisMatch)
+
+			// Hashers without a streaming hash yield the same root
+			hasher, err := HasherByName(scheme.HashAlgorithm)
+			require.NoError(t, err)
+			builder, err = NewBuilder(n, append(opts, WithHasher(sumOnlyHasher{hasher}))...)
+			require.NoError(t, err)
+			for _, file := range files {
+				require.NoError(t, builder.Add(bytes.NewReader(file)))
+			}
+			root, err = builder.Finish()
+			require.NoError(t, err)
+			require.Equal(t, rootHash, root)
+		}
+
+		if scheme.Shape != ShapeRFC6962 {
+			_, err := NewBuilder(0, opts...)
+			require.ErrorIs(t, err, mterr.ErrUnsupportedShape)
+			continue
+		}
+
+		// RFC 6962 trees can be built without knowing the number of files, yielding the root of every prefix
+		builder, err := NewBuilder(0, opts...)
+		require.NoError(t, err)
+		_, err = builder.Finish()
+		require.ErrorIs(t, err, mterr.ErrEmptyFile)
+
+		files := benchmarkLeaves(40)
+		for idx, file := range files {
+			require.NoError(t, builder.Add(bytes.NewReader(file)))
+			require.LessOrEqual(t, len(builder.pending), bits.Len(uint(idx+1)))
+
+			merkleTree, err := BuildMerkleTree(files[:idx+1], opts...)
+			require.NoError(t, err)
+			root, err := builder.Finish()
+			require.NoError(t, err)
+			require.Equal(t, merkleTree.GetMerkleRoot().Hash, root)
+		}
+	}
+
+	// Builders resumed from the peaks of a tree yield its root and the roots of the trees it grows into
+	files := benchmarkLeaves(20)
+	for _, shape := range []Shape{ShapeRFC6962, ShapeMountainRange, ShapeBitcoin} {
+		for n := 1; n <= len(files); n++ {
+			merkleTree, err := BuildMerkleTree(files[:n], WithShape(shape))
+			require.NoError(t, err)
+			peaks, err := merkleTree.Peaks()
+			require.NoError(t, err)
+
+			builder, err := ResumeBuilder(n, peaks, WithShape(shape))
+			require.NoError(t, err)
+			root, err := builder.Finish()
+			require.NoError(t, err)
+			require.Equal(t, merkleTree.GetMerkleRoot().Hash, root)
+
+			for idx := n; idx < len(files); idx++ {
+				require.NoError(t, builder.Add(bytes.NewReader(files[idx])))
+			}
+			grown, err := BuildMerkleTree(files, WithShape(shape))
+			require.NoError(t, err)
+			root, err = builder.Finish()
+			require.NoError(t, err)
+			require.Equal(t, grown.GetMerkleRoot().Hash, root)
+
+			_, err = ResumeBuilder(n, peaks[1:], WithShape(shape))
+			require.ErrorIs(t, err, mterr.ErrInvalidProof)
+		}
+	}
+
+	_, err := ResumeBuilder(1, [][]byte{[]byte("short")}, WithShape(ShapeRFC6962))
+	require.ErrorIs(t, err, mterr.ErrInvalidProof)
+	_, err = ResumeBuilder(0, nil, WithShape(ShapeRFC6962))
+	require.ErrorIs(t, err, mterr.ErrInvalidTreeSize)
+	_, err = ResumeBuilder(1, [][]byte{DefaultHasher().Sum(nil)})
+	require.ErrorIs(t, err, mterr.ErrUnsupportedShape)
+
+	builder, err := NewBuilder(3)
+	require.NoError(t, err)
+	require.NoError(t, builder.Add(bytes.NewReader([]byte("A"))))
+	_, err = builder.Finish()
+	require.ErrorIs(t, err, mterr.ErrLeafCountMisMatch)
+
+	_, err = NewBuilder(-1)
+	require.ErrorIs(t, err, mterr.ErrInvalidTreeSize)
+
+	// Trees built over the leaf digests of files hashed one at a time match the trees built over the files
+	for _, scheme := range []Scheme{{}, {Shape: ShapeRFC6962, ChunkSize: 4}, {Shape: ShapeMountainRange}, {Shape: ShapeBitcoin}} {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for n := 1; n <= len(files); n++ {
+			leaves := make([][]byte, n)
+			for idx, file := range files[:n] {
+				leaves[idx] = LeafHash(file, opts...)
+			}
+			merkleTree, err := BuildMerkleTree(files[:n], opts...)
+			require.NoError(t, err)
+			fromLeaves, err := BuildMerkleTreeFromLeaves(leaves, opts...)
+			require.NoError(t, err)
+			require.Equal(t, merkleTree.GetMerkleRoot().Hash, fromLeaves.GetMerkleRoot().Hash)
+			require.Equal(t, merkleTree.Scheme(), fromLeaves.Scheme())
+
+			proofs, err := fromLeaves.GenerateMerkleProof(n - 1)
+			require.NoError(t, err)
+			ok, err := VerifyProof(merkleTree.GetMerkleRoot().Hash, files[n-1], n-1, n, proofs, opts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	}
+
+	_, err = BuildMerkleTreeFromLeaves(nil)
+	require.ErrorIs(t, err, mterr.ErrEmptyFile)
+	_, err = BuildMerkleTreeFromLeaves([][]byte{[]byte("short")})
+	require.ErrorIs(t, err, mterr.ErrInvalidHash)
+}
+
+func TestChunks(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	const chunkSize = 4
+	files := [][]byte{
+		[]byte("A"),
+		[]byte(""),
+		[]byte("ABCD"),
+		[]byte("ABCDE"),
+		[]byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ"),
+	}
+
+	for _, scheme := range []Scheme{
+		{ChunkSize: chunkSize},
+		{HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962, ChunkSize: chunkSize},
+	} {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		merkleTree, err := BuildMerkleTree(files, opts...)
+		require.NoError(t, err)
+		rootHash := merkleTree.GetMerkleRoot().Hash
+		require.Equal(t, scheme.ChunkSize, merkleTree.Scheme().ChunkSize)
+
+		// Files of at most one chunk keep the leaf they have without chunking
+		unchunked, err := Scheme{HashAlgorithm: scheme.HashAlgorithm, DomainSeparation: scheme.DomainSeparation, Version: scheme.Version, Shape: scheme.Shape}.Options()
+		require.NoError(t, err)
+		for idx, file := range files {
+			require.Equal(t, len(file) <= chunkSize, bytes.Equal(LeafHash(file, unchunked...), LeafHash(file, opts...)), "file%d", idx)
+		}
+
+		for fileIdx, file := range files {
+			fileProofs, err := merkleTree.GenerateMerkleProof(fileIdx)
+			require.NoError(t, err)
+
+			isVerified, err := VerifyProof(rootHash, file, fileIdx, len(files), fileProofs, opts...)
+			require.NoError(t, err)
+			require.True(t, isVerified)
+
+			chunks := Chunks(file, opts...)
+			chunkCount := len(chunks)
+			require.Equal(t, max(1, (len(file)+chunkSize-1)/chunkSize), chunkCount)
+
+			var joined []byte
+			for chunkIdx, chunk := range chunks {
+				joined = append(joined, chunk...)
+
+				chunkProofs, err := GenerateChunkProof(file, chunkIdx, opts...)
+				require.NoError(t, err)
+
+				isVerified, err := VerifyChunkProof(rootHash, chunk, chunkIdx, chunkCount, chunkProofs, fileIdx, len(files), fileProofs, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified, "chunk%d of file%d", chunkIdx, fileIdx)
+
+				// Tampered chunks, chunks of another length and chunks at another index fail
+				tampered := append([]byte(nil), chunk...)
+				if len(tampered) > 0 {
+					tampered[0] ^= 0xff
+					isVerified, err = VerifyChunkProof(rootHash, tampered, chunkIdx, chunkCount, chunkProofs, fileIdx, len(files), fileProofs, opts...)
+					require.NoError(t, err)
+					require.False(t, isVerified)
+				}
+
+				isVerified, err = VerifyChunkProof(rootHash, append(chunk, 'Z'), chunkIdx, chunkCount, chunkProofs, fileIdx, len(files), fileProofs, opts...)
+				require.NoError(t, err)
+				require.False(t, isVerified)
+
+				if chunkCount > 1 {
+					// Proofs of another shape are rejected with an error
+					isVerified, _ = VerifyChunkProof(rootHash, chunk, (chunkIdx+1)%chunkCount, chunkCount, chunkProofs, fileIdx, len(files), fileProofs, opts...)
+					require.False(t, isVerified)
+				}
+			}
+			require.Equal(t, string(file), string(joined))
+
+			_, err = GenerateChunkProof(file, chunkCount, opts...)
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+
+			// The sub-tree built over the chunk digests has the leaf of the file as root and yields the same proofs
+			chunkLeaves := make([][]byte, chunkCount)
+			for chunkIdx, chunk := range chunks {
+				chunkLeaves[chunkIdx] = ChunkLeafHash(chunk, opts...)
+			}
+			chunkTree, err := BuildChunkTree(chunkLeaves, opts...)
+			require.NoError(t, err)
+			require.Equal(t, LeafHash(file, opts...), chunkTree.GetMerkleRoot().Hash)
+			for chunkIdx := range chunks {
+				chunkProofs, err := GenerateChunkProof(file, chunkIdx, opts...)
+				require.NoError(t, err)
+				treeProofs, err := chunkTree.GenerateMerkleProof(chunkIdx)
+				require.NoError(t, err)
+				require.Equal(t, chunkProofs, treeProofs)
+			}
+		}
+
+		// The builder streams the same chunked leaves
+		builder, err := NewBuilder(len(files), opts...)
+		require.NoError(t, err)
+		for _, file := range files {
+			require.NoError(t, builder.Add(bytes.NewReader(file)))
+		}
+		root, err := builder.Finish()
+		require.NoError(t, err)
+		require.Equal(t, rootHash, root)
+	}
+
+	_, err := Scheme{ChunkSize: -1}.Options()
+	require.ErrorIs(t, err, mterr.ErrInvalidChunkSize)
+}
+
+func TestFileHasher(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	random := make([]byte, 8192)
+	rand.New(rand.NewSource(1)).Read(random)
+	files := [][]byte{
+		[]byte(""),
+		[]byte("A"),
+		[]byte("ABCD"),
+		[]byte("ABCDEFGH"),
+		[]byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ"),
+		random,
+	}
+
+	for _, scheme := range []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962},
+		{ChunkSize: 4},
+		{HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionBinary, Shape: ShapeBitcoin, ChunkSize: 4},
+		{DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962, ChunkSize: 64, Chunking: ChunkingContentDefined},
+	} {
+		schemeOpts, err := scheme.Options()
+		require.NoError(t, err)
+		hasher := newConfig(schemeOpts).hasher
+
+		// Hashers without a streaming hash buffer the file and yield the same digests
+		for _, opts := range [][]Option{schemeOpts, append(schemeOpts, WithHasher(sumOnlyHasher{hasher}))} {
+			builder, err := NewBuilder(len(files), opts...)
+			require.NoError(t, err)
+			sparseTree := NewSparseMerkleTree(opts...)
+
+			for fileIdx, file := range files {
+				for pieceIdx, pieceSize := range []int{1, 3, 64, len(file) + 1} {
+					// Chunks are compared by their content, empty chunks may be nil
+					var chunks, expectedChunks [][]byte
+					for _, chunk := range Chunks(file, opts...) {
+						expectedChunks = append(expectedChunks, append([]byte{}, chunk...))
+					}
+					fileHasher := NewFileHasher(func(chunk []byte) {
+						chunks = append(chunks, append([]byte{}, chunk...))
+					}, opts...)
+					for offset := 0; offset < len(file); offset += pieceSize {
+						fileHasher.Write(file[offset:min(offset+pieceSize, len(file))])
+					}
+
+					leaf, valueHash := fileHasher.Finish()
+					require.Equal(t, LeafHash(file, opts...), leaf, "file%d in pieces of %d bytes", fileIdx, pieceSize)
+					require.Equal(t, hasher.Sum(file), valueHash)
+					require.Equal(t, expectedChunks, chunks)
+
+					// The digests do not depend on whether the chunks are collected
+					fileHasher = NewFileHasher(nil, opts...)
+					fileHasher.Write(file)
+					uncollectedLeaf, uncollectedValueHash := fileHasher.Finish()
+					require.Equal(t, leaf, uncollectedLeaf)
+					require.Equal(t, valueHash, uncollectedValueHash)
+
+					if pieceIdx == 0 {
+						require.NoError(t, builder.AddLeaf(leaf))
+						sparseTree.PutValueHash(fmt.Sprintf("file%d", fileIdx), valueHash)
+					}
+				}
+			}
+
+			// Trees over the digests of the file hashers match the ones built over the files
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			root, err := builder.Finish()
+			require.NoError(t, err)
+			require.Equal(t, merkleTree.GetMerkleRoot().Hash, root)
+			require.ErrorIs(t, builder.AddLeaf(root), mterr.ErrLeafCountMisMatch)
+
+			names := make([]string, len(files))
+			for idx := range files {
+				names[idx] = fmt.Sprintf("file%d", idx)
+			}
+			expected, err := BuildSparseMerkleTree(names, files, opts...)
+			require.NoError(t, err)
+			require.Equal(t, expected.RootHash(), sparseTree.RootHash())
+		}
+	}
+
+	builder, err := NewBuilder(0, WithShape(ShapeRFC6962))
+	require.NoError(t, err)
+	require.ErrorIs(t, builder.AddLeaf([]byte("leaf")), mterr.ErrInvalidHash)
+}
+
+func TestEncoding(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	schemes := []Scheme{
+		{},
+		{DomainSeparation: true, Version: VersionBinary},
+		{HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962, ChunkSize: 4},
+		{ChunkSize: 8, Chunking: ChunkingContentDefined},
+	}
+
+	for _, scheme := range schemes {
+		for _, layout := range []Layout{LayoutPointer, LayoutFlat} {
+			if layout == LayoutFlat && scheme.Shape == ShapeRFC6962 {
+				continue
+			}
+			opts, err := scheme.Options()
+			require.NoError(t, err)
+			opts = append(opts, WithLayout(layout))
+
+			for _, n := range []int{1, 2, 3, 7, 16, 33} {
+				files := benchmarkLeaves(n)
+				merkleTree, err := BuildMerkleTree(files, opts...)
+				require.NoError(t, err)
+
+				data, err := merkleTree.MarshalBinary()
+				require.NoError(t, err)
+
+				var decoded MerkleTree
+				require.NoError(t, decoded.UnmarshalBinary(data))
+				require.Equal(t, merkleTree.GetMerkleRoot().Hash, decoded.GetMerkleRoot().Hash)
+				require.Equal(t, n, decoded.LeafCount())
+				require.Equal(t, merkleTree.Scheme(), decoded.Scheme())
+				require.Equal(t, layout, decoded.cfg.layout)
+
+				for idx := range files {
+					expected, err := merkleTree.GenerateMerkleProof(idx)
+					require.NoError(t, err)
+					proofs, err := decoded.GenerateMerkleProof(idx)
+					require.NoError(t, err)
+					require.Equal(t, expected, proofs)
+				}
+
+				// Encoding the decoded tree yields the same bytes, also when streamed
+				var buf bytes.Buffer
+				written, err := decoded.WriteTo(&buf)
+				require.NoError(t, err)
+				require.Equal(t, int64(len(data)), written)
+				require.Equal(t, data, buf.Bytes())
+
+				streamed, err := ReadMerkleTree(&buf)
+				require.NoError(t, err)
+				require.Equal(t, merkleTree.GetMerkleRoot().Hash, streamed.GetMerkleRoot().Hash)
+			}
+		}
+	}
+
+	merkleTree, err := BuildMerkleTree(benchmarkLeaves(5))
+	require.NoError(t, err)
+	data, err := merkleTree.MarshalBinary()
+	require.NoError(t, err)
+
+	// A flipped bit in a digest or the checksum is detected
+	for _, offset := range []int{len(data) - 40, len(data) - 1} {
+		corrupted := bytes.Clone(data)
+		corrupted[offset] ^= 0x01
+		require.ErrorIs(t, new(MerkleTree).UnmarshalBinary(corrupted), mterr.ErrChecksumMisMatch)
+	}
+
+	// Truncated data, trailing data and foreign data are rejected
+	for _, size := range []int{0, 3, 10, len(data) - 1} {
+		require.ErrorIs(t, new(MerkleTree).UnmarshalBinary(data[:size]), mterr.ErrInvalidTreeEncoding)
+	}
+	require.ErrorIs(t, new(MerkleTree).UnmarshalBinary(append(bytes.Clone(data), 0)), mterr.ErrInvalidTreeEncoding)
+	require.ErrorIs(t, new(MerkleTree).UnmarshalBinary([]byte("not a merkle tree")), mterr.ErrInvalidTreeEncoding)
+
+	// Headers claiming more leaves than the data holds are rejected before the storage for them is allocated
+	for _, opts := range [][]Option{nil, {WithLayout(LayoutFlat)}, {WithShape(ShapeMountainRange)}} {
+		merkleTree, err := BuildMerkleTree(benchmarkLeaves(5), opts...)
+		require.NoError(t, err)
+		data, err := merkleTree.MarshalBinary()
+		require.NoError(t, err)
+
+		leafCountOffset := len(encodingMagic) + 2 + 1 + len(SHA256) + 5 + 8 + 2
+		lying := bytes.Clone(data)
+		binary.BigEndian.PutUint64(lying[leafCountOffset:], math.MaxInt32)
+		require.ErrorIs(t, new(MerkleTree).UnmarshalBinary(lying), mterr.ErrInvalidTreeEncoding)
+		require.ErrorIs(t, new(MerkleTree).UnmarshalBinary(lying[:leafCountOffset+8]), mterr.ErrInvalidTreeEncoding)
+	}
+
+	newer := bytes.Clone(data)
+	binary.BigEndian.PutUint16(newer[4:], encodingVersion+1)
+	require.ErrorIs(t, new(MerkleTree).UnmarshalBinary(newer), mterr.ErrUnsupportedEncoding)
+
+	// A tree that failed to decode is left untouched
+	require.Error(t, merkleTree.UnmarshalBinary(newer))
+	require.Equal(t, 5, merkleTree.LeafCount())
+
+	_, err = new(MerkleTree).MarshalBinary()
+	require.ErrorIs(t, err, mterr.ErrEmptyRoot)
+}
+
+func TestCompactProof(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, scheme := range []Scheme{{}, {HashAlgorithm: SHA3_256, DomainSeparation: true, Version: VersionBinary, Shape: ShapeRFC6962}} {
+		opts, err := scheme.Options()
+		require.NoError(t, err)
+
+		for n := 1; n <= 20; n++ {
+			files := benchmarkLeaves(n)
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			rootHash := merkleTree.GetMerkleRoot().Hash
+
+			for idx, file := range files {
+				proof, err := merkleTree.GenerateCompactProof(idx)
+				require.NoError(t, err)
+				require.Equal(t, idx, proof.LeafIdx)
+				require.Equal(t, n, proof.LeafCount)
+
+				// The compact proof holds the digests of the legacy proof in the same order
+				proofs, err := merkleTree.GenerateMerkleProof(idx)
+				require.NoError(t, err)
+				legacy, err := NewProof(idx, n, proofs)
+				require.NoError(t, err)
+				require.Equal(t, proof, legacy)
+				for i, node := range proof.TreeNodes() {
+					require.Equal(t, proofs[i].Hash, node.Hash)
+				}
+
+				// The binary and the text form decode to the same proof
+				data, err := proof.MarshalBinary()
+				require.NoError(t, err)
+				var decoded Proof
+				require.NoError(t, decoded.UnmarshalBinary(data))
+				require.Equal(t, proof.LeafIdx, decoded.LeafIdx)
+				require.Equal(t, proof.LeafCount, decoded.LeafCount)
+				require.Equal(t, len(proof.Siblings), len(decoded.Siblings))
+				for i := range proof.Siblings {
+					require.Equal(t, proof.Siblings[i], decoded.Siblings[i])
+				}
+
+				text, err := proof.MarshalText()
+				require.NoError(t, err)
+				require.Equal(t, proof.String(), string(text))
+				var parsed Proof
+				require.NoError(t, parsed.UnmarshalText(text))
+				require.Equal(t, decoded, parsed)
+
+				isVerified, err := VerifyCompactProof(rootHash, file, idx, n, &parsed, opts...)
+				require.NoError(t, err)
+				require.True(t, isVerified)
+
+				// The claimed index and tree size have to match the trusted ones
+				_, err = VerifyCompactProof(rootHash, file, idx, n+1, &parsed, opts...)
+				require.ErrorIs(t, err, mterr.ErrInvalidProof)
+				if n > 1 {
+					_, err = VerifyCompactProof(rootHash, file, (idx+1)%n, n, &parsed, opts...)
+					require.ErrorIs(t, err, mterr.ErrInvalidProof)
+				}
+
+				// Binary proofs are far smaller than the JSON proofs with hexadecimal digests
+				legacyJSON, err := json.Marshal(proofs)
+				require.NoError(t, err)
+				require.Less(t, len(data), len(legacyJSON)/2+8)
+			}
+		}
+	}
+
+	// Truncated, trailing and foreign data is rejected
+	merkleTree, err := BuildMerkleTree(benchmarkLeaves(5))
+	require.NoError(t, err)
+	proof, err := merkleTree.GenerateCompactProof(3)
+	require.NoError(t, err)
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+
+	for size := 0; size < len(data); size++ {
+		require.ErrorIs(t, new(Proof).UnmarshalBinary(data[:size]), mterr.ErrInvalidProofEncoding, "size %d", size)
+	}
+	require.ErrorIs(t, new(Proof).UnmarshalBinary(append(bytes.Clone(data), 0)), mterr.ErrInvalidProofEncoding)
+	require.ErrorIs(t, new(Proof).UnmarshalBinary(append([]byte{proofEncodingVersion + 1}, data[1:]...)), mterr.ErrInvalidProofEncoding)
+	require.ErrorIs(t, new(Proof).UnmarshalText([]byte("not base64!")), mterr.ErrInvalidProofEncoding)
+
+	// Indices beyond the tree size and empty nodes are rejected
+	_, err = NewProof(5, 5, nil)
+	require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+	_, err = NewProof(0, 5, []*TreeNode{nil})
+	require.ErrorIs(t, err, mterr.ErrEmptyNode)
+	_, err = (&Proof{LeafIdx: 5, LeafCount: 5}).MarshalBinary()
+	require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+}
+
+func TestDiff(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, opts := range [][]Option{
+		nil,
+		{WithLayout(LayoutFlat)},
+		{WithShape(ShapeRFC6962), WithDomainSeparation(true)},
+		{WithShape(ShapeMountainRange)},
+	} {
+		for n := 1; n <= 33; n++ {
+			files := benchmarkLeaves(n)
+			merkleTree, err := BuildMerkleTree(files, opts...)
+			require.NoError(t, err)
+			height := bits.Len(uint(n - 1))
+
+			// Every level lists its nodes from left to right, and all levels together hold every node once
+			total := 0
+			for level := 0; level <= height; level++ {
+				width := newLevelWidths(merkleTree.cfg).width(n, level)
+				nodes, err := merkleTree.GetNodes(level, 0, width-1)
+				require.NoError(t, err)
+				require.Len(t, nodes, width)
+				for pos, node := range nodes {
+					require.Nil(t, node.Left)
+					if pos > 0 {
+						require.Greater(t, node.LeftIdx, nodes[pos-1].RightIdx)
+					}
+					single, err := merkleTree.GetNodes(level, pos, pos)
+					require.NoError(t, err)
+					require.Equal(t, []*TreeNode{node}, single)
+				}
+				total += width
+
+				_, err = merkleTree.GetNodes(level, 0, width)
+				require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+			}
+			require.Equal(t, 2*n-1, total)
+			_, err = merkleTree.GetNodes(height+1, 0, 0)
+			require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+
+			rnd := rand.New(rand.NewSource(int64(n)))
+			for _, changed := range [][]int{nil, {0}, {n - 1}, rnd.Perm(n)[:rnd.Intn(n)+1]} {
+				modified := make([][]byte, n)
+				copy(modified, files)
+				for _, idx := range changed {
+					modified[idx] = append(bytes.Clone(files[idx]), 0xff)
+				}
+				other, err := BuildMerkleTree(modified, opts...)
+				require.NoError(t, err)
+
+				var expected []int
+				for idx := range files {
+					if !bytes.Equal(files[idx], modified[idx]) {
+						expected = append(expected, idx)
+					}
+				}
+
+				diff, err := Diff(merkleTree, other)
+				require.NoError(t, err)
+				require.Equal(t, expected, diff)
+
+				// Walking the other tree level by level finds the same leaves and only requests
+				// the nodes on the paths from the root to the differing leaves and their siblings
+				requested := 0
+				diff, err = DiffLevels(merkleTree, func(level, from, to int) ([]*TreeNode, error) {
+					requested += to - from + 1
+					return other.GetNodes(level, from, to)
+				})
+				require.NoError(t, err)
+				require.Equal(t, expected, diff)
+				require.LessOrEqual(t, requested, 1+2*len(expected)*height)
+			}
+		}
+	}
+
+	// Trees of different schemes or sizes cannot be compared
+	files := benchmarkLeaves(6)
+	merkleTree, err := BuildMerkleTree(files)
+	require.NoError(t, err)
+	rfcTree, err := BuildMerkleTree(files, WithShape(ShapeRFC6962))
+	require.NoError(t, err)
+	smallTree, err := BuildMerkleTree(files[:5])
+	require.NoError(t, err)
+
+	_, err = Diff(merkleTree, rfcTree)
+	require.ErrorIs(t, err, mterr.ErrSchemeMisMatch)
+	_, err = Diff(merkleTree, smallTree)
+	require.ErrorIs(t, err, mterr.ErrLeafCountMisMatch)
+	_, err = DiffLevels(merkleTree, smallTree.GetNodes)
+	require.ErrorIs(t, err, mterr.ErrLeafCountMisMatch)
+	_, err = DiffLevels(merkleTree, rfcTree.GetNodes)
+	require.ErrorIs(t, err, mterr.ErrSchemeMisMatch)
+	_, err = merkleTree.GetNodes(0, 1, 0)
+	require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+}
+
+func TestExport(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	// countNodes counts the exported nodes by their role on the proof path
+	var countNodes func(node *exportNode, roles map[string]int)
+	countNodes = func(node *exportNode, roles map[string]int) {
+		roles[node.Proof]++
+		if node.Truncated {
+			roles["truncated"]++
+		}
+		if node.Left != nil {
+			countNodes(node.Left, roles)
+			countNodes(node.Right, roles)
+		}
+	}
+
+	for _, opts := range [][]Option{
+		nil,
+		{WithLayout(LayoutFlat)},
+		{WithShape(ShapeRFC6962), WithDomainSeparation(true)},
+		{WithShape(ShapeMountainRange)},
+	} {
+		for _, n := range []int{1, 2, 5, 16, 21} {
+			merkleTree, err := BuildMerkleTree(benchmarkLeaves(n), opts...)
+			require.NoError(t, err)
+			height := bits.Len(uint(n - 1))
+
+			// The whole tree is exported by default
+			var buf bytes.Buffer
+			require.NoError(t, merkleTree.Export(&buf, FormatJSON))
+			var root exportNode
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &root))
+			require.Equal(t, EncodeHash(merkleTree.GetMerkleRoot().Hash), root.Hash)
+			roles := make(map[string]int)
+			countNodes(&root, roles)
+			require.Equal(t, map[string]int{"": 2*n - 1}, roles)
+
+			for _, leafIdx := range []int{0, n / 2, n - 1} {
+				exportOpts := []ExportOption{WithMaxDepth(1), WithProofPath(leafIdx)}
+
+				// Only the first level is expanded besides the proof path, whose siblings make up the proof
+				buf.Reset()
+				require.NoError(t, merkleTree.Export(&buf, FormatJSON, exportOpts...))
+				root = exportNode{}
+				require.NoError(t, json.Unmarshal(buf.Bytes(), &root))
+				proofs, err := merkleTree.GenerateMerkleProof(leafIdx)
+				require.NoError(t, err)
+
+				var siblings []string
+				for node := &root; node.Left != nil; {
+					require.Equal(t, proofPath, node.Proof)
+					if node.Left.Proof == proofPath {
+						siblings, node = append(siblings, node.Right.Hash), node.Left
+					} else {
+						siblings, node = append(siblings, node.Left.Hash), node.Right
+					}
+				}
+				require.Len(t, siblings, len(proofs))
+				for i, proof := range proofs {
+					require.Equal(t, EncodeHash(proof.Hash), siblings[len(siblings)-1-i])
+				}
+
+				roles = make(map[string]int)
+				countNodes(&root, roles)
+				require.Equal(t, len(proofs)+1, roles[proofPath])
+				require.Equal(t, len(proofs), roles[proofSibling])
+				require.LessOrEqual(t, roles[""], 2)
+				if height > 2 {
+					require.Positive(t, roles["truncated"])
+				}
+
+				// A tree fetched level by level renders the same in every format
+				fetched, err := FetchTree(merkleTree.GetNodes, n, merkleTree.Scheme(), exportOpts...)
+				require.NoError(t, err)
+				for _, format := range ExportFormats() {
+					var expected, actual bytes.Buffer
+					require.NoError(t, merkleTree.Export(&expected, ExportFormat(format), exportOpts...))
+					require.NoError(t, Export(&actual, fetched, ExportFormat(format), exportOpts...))
+					require.Equal(t, expected.String(), actual.String(), format)
+				}
+			}
+		}
+	}
+
+	// The graph formats highlight the proof path and dash the truncated nodes
+	merkleTree, err := BuildMerkleTree(benchmarkLeaves(8), WithVersion(VersionBinary))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, merkleTree.Export(&buf, FormatDOT, WithMaxDepth(1), WithProofPath(5)))
+	dot := buf.String()
+	require.True(t, strings.HasPrefix(dot, "digraph merkle {\n"))
+	require.Contains(t, dot, "\tn0_7 -> n0_3;\n\tn0_7 -> n4_7;\n")
+	require.Contains(t, dot, fmt.Sprintf("\tn5_5 [label=\"[5, 5]\\n%s\", color=\"#d62728\", penwidth=2];\n", EncodeHash(merkleTree.LeafHash(benchmarkLeaves(8)[5]))[:8]))
+	require.Contains(t, dot, "\tn0_3 [label=\"[0, 3]\\n")
+	require.Contains(t, dot, "fillcolor=\"#aec7e8\", style=\"filled,dashed\"];\n")
+	require.NotContains(t, dot, "n0_1")
+
+	buf.Reset()
+	require.NoError(t, merkleTree.Export(&buf, FormatMermaid, WithMaxDepth(1), WithProofPath(5)))
+	mermaid := buf.String()
+	require.True(t, strings.HasPrefix(mermaid, "graph TD\n"))
+	require.Contains(t, mermaid, "\tn0_7 --> n0_3\n")
+	require.Contains(t, mermaid, "\tclass n0_7,n4_7,n4_5,n5_5 path\n")
+	require.Contains(t, mermaid, "\tclass n0_3,n4_4,n6_7 sibling\n")
+	require.Contains(t, mermaid, "\tclass n0_3,n6_7 truncated\n")
+
+	// Unknown formats, leaves beyond the tree and empty trees are refused
+	require.ErrorIs(t, merkleTree.Export(&buf, ExportFormat("svg")), mterr.ErrUnknownExportFormat)
+	require.ErrorIs(t, merkleTree.Export(&buf, FormatDOT, WithProofPath(8)), mterr.ErrIndexOutOfBound)
+	require.ErrorIs(t, Export(&buf, nil, FormatDOT), mterr.ErrEmptyRoot)
+	_, err = FetchTree(merkleTree.GetNodes, 7, merkleTree.Scheme())
+	require.ErrorIs(t, err, mterr.ErrLeafCountMisMatch)
+}
+
+func TestStats(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	blake, err := HasherByName(BLAKE2b256)
+	require.NoError(t, err)
+
+	// Every shape and layout holds 2n-1 digests over ceil(log2(n))+1 levels. Bitcoin trees additionally count
+	// the nodes hashed from the last node of a level with an odd number of nodes and its duplicate
+	nodeCount := func(shape Shape, n int) int {
+		if shape != ShapeBitcoin {
+			return 2*n - 1
+		}
+		count := n
+		for ; n > 1; n = (n + 1) / 2 {
+			count += (n + 1) / 2
+		}
+		return count
+	}
+	for _, opts := range [][]Option{
+		nil,
+		{WithLayout(LayoutFlat)},
+		{WithShape(ShapeRFC6962), WithHasher(blake)},
+		{WithShape(ShapeMountainRange)},
+		{WithShape(ShapeBitcoin)},
+	} {
+		for n := 1; n <= 9; n++ {
+			merkleTree, err := BuildMerkleTree(benchmarkLeaves(n), opts...)
+			require.NoError(t, err)
+
+			stats := merkleTree.Stats()
+			shape := merkleTree.Scheme().Shape
+			require.Equal(t, n, stats.LeafCount)
+			require.Equal(t, nodeCount(shape, n), stats.NodeCount)
+			require.Equal(t, bits.Len(uint(n-1))+1, stats.Height)
+			require.Equal(t, stats.NodeCount*merkleTree.Hasher().Size(), stats.TotalBytes)
+			require.Equal(t, merkleTree.Hasher().Name(), stats.HashAlgorithm)
+			require.Positive(t, stats.BuildDuration)
+
+			// Decoded trees were not built, appending adds two nodes or as many as the Bitcoin tree lifts
+			data, err := merkleTree.MarshalBinary()
+			require.NoError(t, err)
+			decoded, err := ReadMerkleTree(bytes.NewReader(data))
+			require.NoError(t, err)
+			stats.BuildDuration = 0
+			require.Equal(t, stats, decoded.Stats())
+
+			if shape == ShapeMidpoint {
+				require.ErrorIs(t, merkleTree.Append([]byte("appended")), mterr.ErrUnsupportedShape)
+				continue
+			}
+			require.NoError(t, merkleTree.Append([]byte("appended")))
+			require.Equal(t, nodeCount(shape, n+1), merkleTree.Stats().NodeCount)
+			require.Equal(t, bits.Len(uint(n))+1, merkleTree.Stats().Height)
+		}
+	}
+
+	// The Bitcoin tree over five leaves duplicates the fifth leaf and its two parents up to the root
+	bitcoinTree, err := BuildMerkleTree(benchmarkLeaves(5), WithShape(ShapeBitcoin))
+	require.NoError(t, err)
+	require.Equal(t, 5+3+2+1, bitcoinTree.Stats().NodeCount)
+	require.Equal(t, 4, bitcoinTree.Stats().Height)
+}
+
+// benchmarkSizes are the numbers of leaves the benchmarks are run against.
+var benchmarkSizes = []int{1_000, 10_000, 100_000, 1_000_000, 10_000_000}
+
+// benchmarkLeaves returns `n` distinct 8 byte leaves.
+func benchmarkLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = binary.BigEndian.AppendUint64(nil, uint64(i))
+	}
+	return leaves
+}
+
+// skipLargeBenchmark skips the trees with more than a million leaves in short mode,
+// as they need several GB of memory.
+func skipLargeBenchmark(b *testing.B, n int) {
+	if testing.Short() && n > 1_000_000 {
+		b.Skipf("skipping %d leaves in short mode", n)
+	}
+}
+
+// BenchmarkGenerateMerkleProof measures the proof generation across tree sizes. The time per
+// proof and the number of siblings per proof grow with log(n), i.e. by a constant amount for
+// every tenfold increase of the number of leaves.
+func BenchmarkGenerateMerkleProof(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("leaves=%d", n), func(b *testing.B) {
+			skipLargeBenchmark(b, n)
+			merkleTree, err := BuildMerkleTree(benchmarkLeaves(n), WithVersion(VersionBinary))
+			require.NoError(b, err)
+
+			var siblings int
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				merkleProofs, err := merkleTree.GenerateMerkleProof((i * 7919) % n)
+				if err != nil {
+					b.Fatal(err)
+				}
+				siblings += len(merkleProofs)
+			}
+			b.ReportMetric(float64(siblings)/float64(b.N), "siblings/op")
+		})
+	}
+}
+
+// BenchmarkBuildMerkleTree compares the pointer and the flat layout across tree sizes. Besides
+// the allocations made while building, it reports the heap the finished tree retains per leaf.
+func BenchmarkBuildMerkleTree(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	layouts := []struct {
+		name   string
+		layout Layout
+	}{
+		{"pointer", LayoutPointer},
+		{"flat", LayoutFlat},
+	}
+
+	for _, n := range benchmarkSizes {
+		for _, layout := range layouts {
+			b.Run(fmt.Sprintf("leaves=%d/layout=%s", n, layout.name), func(b *testing.B) {
+				skipLargeBenchmark(b, n)
+				leaves := benchmarkLeaves(n)
+
+				var retained uint64
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					var before, after runtime.MemStats
+					b.StopTimer()
+					runtime.GC()
+					runtime.ReadMemStats(&before)
+					b.StartTimer()
+
+					merkleTree, err := BuildMerkleTree(leaves, WithVersion(VersionBinary), WithLayout(layout.layout))
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					b.StopTimer()
+					runtime.GC()
+					runtime.ReadMemStats(&after)
+					retained += after.HeapAlloc - before.HeapAlloc
+					runtime.KeepAlive(merkleTree)
+					b.StartTimer()
+				}
+				b.ReportMetric(float64(retained)/float64(b.N)/float64(n), "retained-B/leaf")
+			})
+		}
+	}
+}
+
+// BenchmarkParallelBuild compares the serial build with builds by several workers across tree sizes.
+func BenchmarkParallelBuild(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, n := range benchmarkSizes {
+		for _, workers := range []int{1, 2, 4, 8} {
+			b.Run(fmt.Sprintf("leaves=%d/workers=%d", n, workers), func(b *testing.B) {
+				skipLargeBenchmark(b, n)
+				leaves := benchmarkLeaves(n)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := BuildMerkleTree(leaves, WithVersion(VersionBinary), WithWorkers(workers)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
 // Run the tests
 func TestMain(m *testing.M) {
 	m.Run()
@@ -0,0 +1,184 @@
+package merkle
+
+import (
+	"math/bits"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// mountainRange is the append-only storage of a Merkle tree with the `ShapeMountainRange` shape.
+//
+// The leaves are covered by perfect binary trees, the mountains, whose sizes are the powers of two in
+// the binary representation of the leaf count from the largest to the smallest. The digests of all
+// their nodes are stored in post-order, which is the order they are created in while appending. The
+// node covering the leaves `[l, r]` with `r-l+1 = 2^h` sits at position `2r - popcount(r) + h`. Since
+// a mountain is never modified once complete, appending only adds nodes behind the existing ones.
+//
+// The roots of the mountains, the peaks, are bagged from right to left into a single root: the bag of
+// the peaks `i, i+1, ...` hashes peak `i` with the bag of the peaks right of it. The bag of the peaks
+// starting at a leaf is the node covering that leaf up to the last one in a tree with the `ShapeRFC6962`
+// shape, hence both trees have the same root hash and the same proofs.
+type mountainRange struct {
+	leafCount int    // Number of leaves
+	size      int    // Digest size in bytes
+	nodes     []byte // Digests of the nodes of all mountains in post-order
+	bags      []byte // bags[i] is the digest of the bag of the peaks i, i+1, ... for all but the last peak
+}
+
+// newMountainRange allocates the storage of a mountain range over `n` leaves with all digests zeroed.
+func newMountainRange(cfg *config, n int) *mountainRange {
+	size := cfg.hasher.Size()
+	return &mountainRange{
+		leafCount: n,
+		size:      size,
+		nodes:     make([]byte, mountainNodeCount(n)*size),
+		bags:      make([]byte, (bits.OnesCount(uint(n))-1)*size),
+	}
+}
+
+// buildMountainRange appends the given leaf digests one by one and bags the peaks once at the end.
+func buildMountainRange(cfg *config, leaves [][]byte) *mountainRange {
+	mr := &mountainRange{size: cfg.hasher.Size()}
+	mr.nodes = make([]byte, 0, mountainNodeCount(len(leaves))*mr.size)
+	for _, leaf := range leaves {
+		mr.push(cfg, leaf)
+	}
+	mr.bag(cfg)
+	return mr
+}
+
+// push appends the leaf digest and merges the mountains of equal size it completes. The nodes of the
+// mountains are only added behind the existing ones, the bags have to be recomputed afterwards.
+func (mr *mountainRange) push(cfg *config, leaf []byte) {
+	idx := mr.leafCount
+	mr.leafCount++
+	mr.nodes = append(mr.nodes, leaf...)
+
+	// Every set low bit of the leaf index marks a mountain of that height on the left to merge with
+	digest := leaf
+	for h := 0; idx>>h&1 == 1; h++ {
+		left := mr.node(idx-1<<(h+1)+1, idx-1<<h)
+		digest = cfg.hashNode(left, digest)
+		mr.nodes = append(mr.nodes, digest...)
+	}
+}
+
+// bag recomputes the bags of the peaks from right to left.
+func (mr *mountainRange) bag(cfg *config) {
+	peaks := mr.peaks()
+	mr.bags = mr.bags[:0]
+	if len(peaks) < 2 {
+		return
+	}
+
+	bags := make([][]byte, len(peaks)-1)
+	digest := mr.node(peaks[len(peaks)-1].l, peaks[len(peaks)-1].r)
+	for i := len(peaks) - 2; i >= 0; i-- {
+		digest = cfg.hashNode(mr.node(peaks[i].l, peaks[i].r), digest)
+		bags[i] = digest
+	}
+	for _, digest := range bags {
+		mr.bags = append(mr.bags, digest...)
+	}
+}
+
+// update overwrites the digest of the leaf at `leafIdx`, recomputes its ancestors within its mountain
+// in place and bags the peaks again.
+func (mr *mountainRange) update(cfg *config, leafIdx int, leaf []byte) {
+	copy(mr.node(leafIdx, leafIdx), leaf)
+
+	for _, peak := range mr.peaks() {
+		if leafIdx > peak.r {
+			continue
+		}
+
+		// Mountains start at a multiple of their size, so the ancestor of height h
+		// starts at the leaf index with its low h bits cleared
+		for h := 1; 1<<h <= peak.r-peak.l+1; h++ {
+			l := leafIdx &^ (1<<h - 1)
+			mid, r := l+1<<(h-1)-1, l+1<<h-1
+			copy(mr.node(l, r), cfg.hashNode(mr.node(l, mid), mr.node(mid+1, r)))
+		}
+		break
+	}
+	mr.bag(cfg)
+}
+
+// peaks returns the leaves covered by each mountain from left to right.
+func (mr *mountainRange) peaks() []nodeRef {
+	var peaks []nodeRef
+	l := 0
+	for h := bits.Len(uint(mr.leafCount)) - 1; h >= 0; h-- {
+		if mr.leafCount>>h&1 == 1 {
+			peaks = append(peaks, nodeRef{l: l, r: l + 1<<h - 1})
+			l += 1 << h
+		}
+	}
+	return peaks
+}
+
+// node returns the digest of the node of a mountain covering the leaves `[l, r]`. The returned slice aliases the storage.
+func (mr *mountainRange) node(l, r int) []byte {
+	pos := (2*r - bits.OnesCount(uint(r)) + bits.Len(uint(r-l))) * mr.size
+	return mr.nodes[pos : pos+mr.size : pos+mr.size]
+}
+
+// digest returns the digest of the given node. Nodes covering a power of two leaves lie within a
+// mountain, all others are the bags of the peaks starting at their first leaf.
+func (mr *mountainRange) digest(n nodeRef) []byte {
+	if size := n.r - n.l + 1; size&(size-1) == 0 {
+		return mr.node(n.l, n.r)
+	}
+	pos := bits.OnesCount(uint(n.l)) * mr.size
+	return mr.bags[pos : pos+mr.size : pos+mr.size]
+}
+
+// read reads the digests of the subtree rooted at the node in pre-order into the storage.
+func (mr *mountainRange) read(cfg *config, dec *decoder, n nodeRef) {
+	dec.read(mr.digest(n))
+	if n.l == n.r || dec.err != nil {
+		return
+	}
+
+	mid := cfg.split(n.l, n.r)
+	mr.read(cfg, dec, nodeRef{l: n.l, r: mid})
+	mr.read(cfg, dec, nodeRef{l: mid + 1, r: n.r})
+}
+
+// mountainNodeCount returns the number of nodes of the mountains over `n` leaves.
+func mountainNodeCount(n int) int {
+	return 2*n - bits.OnesCount(uint(n))
+}
+
+// Peaks returns the digests of the peaks from left to right. Trees with the `ShapeRFC6962` or the
+// `ShapeBitcoin` shape have the same peaks, which are the perfect subtrees on the right border of the
+// tree. Together with the number of leaves they are all `ResumeBuilder` needs to append to the tree.
+func (mt *MerkleTree) Peaks() ([][]byte, error) {
+	if !mt.cfg.powerOfTwoSplit() {
+		return nil, mterr.ErrUnsupportedShape
+	}
+
+	var peaks [][]byte
+	for curr := mt.rootRef(); ; {
+		if size := curr.r - curr.l + 1; size&(size-1) == 0 {
+			return append(peaks, append([]byte(nil), mt.digest(curr)...)), nil
+		}
+		left, right := mt.children(curr)
+		peaks = append(peaks, append([]byte(nil), mt.digest(left)...))
+		curr = right
+	}
+}
+
+// BagPeaks bags the digests of the peaks from right to left into the root hash of the mountain range.
+func BagPeaks(peaks [][]byte, opts ...Option) ([]byte, error) {
+	if len(peaks) == 0 {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	cfg := newConfig(opts)
+	digest := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		digest = cfg.hashNode(peaks[i], digest)
+	}
+	return digest, nil
+}
@@ -0,0 +1,125 @@
+package merkle
+
+import (
+	"bytes"
+	"sort"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// GenerateMultiProof generates a single Merkle proof for several leaf indices at once.
+// The indices are sorted and deduplicated. The proof consists of the roots of all maximal subtrees
+// that contain none of the requested leaves, ordered from left to right. Siblings shared between
+// the paths of the requested leaves, and siblings that can be recomputed from the requested leaves
+// themselves, are therefore only included once or not at all.
+func (mt *MerkleTree) GenerateMultiProof(leafIdxs []int) ([]*TreeNode, error) {
+	idxs, err := normalizeIndices(leafIdxs, mt.LeafCount())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*TreeNode
+	var walk func(n nodeRef, idxs []int)
+	walk = func(n nodeRef, idxs []int) {
+		switch {
+		case len(idxs) == 0:
+			result = append(result, mt.treeNode(n))
+		case n.l != n.r:
+			left, right := mt.children(n)
+			split := sort.SearchInts(idxs, right.l)
+			walk(left, idxs[:split])
+			walk(right, idxs[split:])
+		}
+	}
+	walk(mt.rootRef(), idxs)
+	return result, nil
+}
+
+// VerifyMultiProof verifies a multi-proof without access to the Merkle tree. It checks that each
+// `leaves[i]` is the file stored at `leafIdxs[i]` in a tree built over `leafCount` files whose root hash
+// is `rootHash`. The indices may be passed in any order and repeatedly. As with `VerifyProof`, the
+// position of every proof node is derived from the indices and the leaf count alone.
+func VerifyMultiProof(rootHash []byte, leafIdxs []int, leaves [][]byte, leafCount int, proofs []*TreeNode, opts ...Option) (bool, error) {
+	if len(leafIdxs) != len(leaves) {
+		return false, mterr.ErrInvalidProof
+	}
+	if leafCount <= 0 {
+		return false, mterr.ErrEmptyRoot
+	}
+
+	idxs, err := normalizeIndices(leafIdxs, leafCount)
+	if err != nil {
+		return false, err
+	}
+
+	cfg := newConfig(opts)
+	leafHashes := make(map[int][]byte, len(idxs))
+	for i, idx := range leafIdxs {
+		leafHash := cfg.hashFile(leaves[i])
+		if other, ok := leafHashes[idx]; ok && !bytes.Equal(other, leafHash) {
+			return false, nil
+		}
+		leafHashes[idx] = leafHash
+	}
+
+	next := 0
+	var fold func(l, r int, idxs []int) ([]byte, error)
+	fold = func(l, r int, idxs []int) ([]byte, error) {
+		switch {
+		case len(idxs) == 0:
+			if next >= len(proofs) {
+				return nil, mterr.ErrInvalidProof
+			}
+			proof := proofs[next]
+			next++
+			if proof == nil {
+				return nil, mterr.ErrEmptyNode
+			}
+			return proof.Hash, nil
+		case l == r:
+			return leafHashes[l], nil
+		}
+
+		mid := cfg.split(l, r)
+		split := sort.SearchInts(idxs, mid+1)
+		left, err := fold(l, mid, idxs[:split])
+		if err != nil {
+			return nil, err
+		}
+		right, err := fold(mid+1, r, idxs[split:])
+		if err != nil {
+			return nil, err
+		}
+		return cfg.hashChildren(l, mid, r, left, right), nil
+	}
+
+	merkleHash, err := fold(0, leafCount-1, idxs)
+	if err != nil {
+		return false, err
+	}
+	if next != len(proofs) {
+		return false, mterr.ErrInvalidProof
+	}
+	return bytes.Equal(merkleHash, rootHash), nil
+}
+
+// normalizeIndices returns the given leaf indices sorted and without duplicates.
+func normalizeIndices(leafIdxs []int, leafCount int) ([]int, error) {
+	if len(leafIdxs) == 0 {
+		return nil, mterr.ErrEmptyIndices
+	}
+
+	idxs := append([]int(nil), leafIdxs...)
+	sort.Ints(idxs)
+	if idxs[0] < 0 || idxs[len(idxs)-1] >= leafCount {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	unique := idxs[:1]
+	for _, idx := range idxs[1:] {
+		if idx != unique[len(unique)-1] {
+			unique = append(unique, idx)
+		}
+	}
+	return unique, nil
+}
@@ -0,0 +1,88 @@
+package merkle
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelMinLeaves is the smallest number of leaves a chunk of leaf hashes or a subtree must cover
+// to be handed to another goroutine. Smaller pieces are cheaper to hash than to schedule.
+const parallelMinLeaves = 1024
+
+// WithWorkers bounds the number of goroutines hashing leaves and building subtrees concurrently.
+// Trees are built with `runtime.GOMAXPROCS(0)` workers by default, a single worker builds the tree
+// serially. The resulting tree does not depend on the number of workers.
+func WithWorkers(workers int) Option {
+	return func(cfg *config) {
+		if workers > 0 {
+			cfg.workers = workers
+		}
+	}
+}
+
+// workerCount returns the configured number of workers, defaulting to `runtime.GOMAXPROCS(0)`.
+func (cfg *config) workerCount() int {
+	if cfg.workers > 0 {
+		return cfg.workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// forker runs work on additional goroutines as long as one of its tokens is free. The goroutine
+// calling it counts as a worker too, so a forker for `n` workers holds `n-1` tokens.
+type forker struct {
+	tokens chan struct{}
+}
+
+// newForker returns a forker for the configured number of workers.
+func newForker(cfg *config) *forker {
+	return &forker{tokens: make(chan struct{}, cfg.workerCount()-1)}
+}
+
+// fork runs `fn` on a new goroutine if it covers at least `parallelMinLeaves` leaves and a token is free,
+// otherwise it runs `fn` right away. The returned function waits until `fn` has finished.
+func (f *forker) fork(leafCount int, fn func()) (wait func()) {
+	if f != nil && leafCount >= parallelMinLeaves {
+		select {
+		case f.tokens <- struct{}{}:
+			done := make(chan struct{})
+			go func() {
+				defer func() { <-f.tokens }()
+				defer close(done)
+				fn()
+			}()
+			return func() { <-done }
+		default:
+		}
+	}
+
+	fn()
+	return func() {}
+}
+
+// hashLeaves calculates the leaf digests of the files, splitting them into one contiguous chunk per worker.
+func hashLeaves(cfg *config, files [][]byte) [][]byte {
+	leaves := make([][]byte, len(files))
+	chunks := min(cfg.workerCount(), (len(files)+parallelMinLeaves-1)/parallelMinLeaves)
+	if chunks <= 1 {
+		for idx, file := range files {
+			leaves[idx] = cfg.hashFile(file)
+		}
+		return leaves
+	}
+
+	var wg sync.WaitGroup
+	chunkSize := (len(files) + chunks - 1) / chunks
+	for from := 0; from < len(files); from += chunkSize {
+		to := min(from+chunkSize, len(files))
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			for idx := from; idx < to; idx++ {
+				leaves[idx] = cfg.hashFile(files[idx])
+			}
+		}(from, to)
+	}
+	wg.Wait()
+	return leaves
+}
@@ -0,0 +1,169 @@
+package merkle
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// proofEncodingVersion is the version of the binary proof format `MarshalBinary` writes.
+const proofEncodingVersion = 1
+
+// Proof is the compact form of a Merkle proof. It only holds the raw digests of the siblings ordered
+// from the leaf up to the root. Whether a sibling sits to the left or to the right is not stored,
+// it follows from the leaf index and the tree size like in `VerifyProof`.
+type Proof struct {
+	LeafIdx   int      // Index of the proven leaf
+	LeafCount int      // Number of leaves of the tree the proof was generated for
+	Siblings  [][]byte // Digests of the siblings from the leaf up to the root
+}
+
+// GenerateCompactProof generates the Merkle proof for the given leaf index in its compact form.
+func (mt *MerkleTree) GenerateCompactProof(leafIdx int) (*Proof, error) {
+	proofs, err := mt.GenerateMerkleProof(leafIdx)
+	if err != nil {
+		return nil, err
+	}
+	return NewProof(leafIdx, mt.LeafCount(), proofs)
+}
+
+// NewProof converts a proof path in the legacy `TreeNode` format, as returned by `GenerateMerkleProof`
+// and sent over gRPC, into its compact form. Only the digests are kept, the indices of the nodes are dropped.
+func NewProof(leafIdx, leafCount int, proofs []*TreeNode) (*Proof, error) {
+	switch {
+	case leafCount <= 0:
+		return nil, mterr.ErrEmptyRoot
+	case leafIdx < 0 || leafIdx >= leafCount:
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	siblings := make([][]byte, len(proofs))
+	for idx, proof := range proofs {
+		if proof == nil {
+			return nil, mterr.ErrEmptyNode
+		}
+		if len(proof.Hash) != len(proofs[0].Hash) {
+			return nil, mterr.ErrInvalidProof
+		}
+		siblings[idx] = proof.Hash
+	}
+	return &Proof{LeafIdx: leafIdx, LeafCount: leafCount, Siblings: siblings}, nil
+}
+
+// TreeNodes converts the proof back into the legacy `TreeNode` format. The nodes only carry their digests.
+func (p *Proof) TreeNodes() []*TreeNode {
+	proofs := make([]*TreeNode, len(p.Siblings))
+	for idx, sibling := range p.Siblings {
+		proofs[idx] = &TreeNode{Hash: sibling}
+	}
+	return proofs
+}
+
+// VerifyCompactProof verifies a compact proof like `VerifyProof`. The leaf index and the tree size the
+// proof claims have to match `leafIdx` and `leafCount`, which the caller trusts.
+func VerifyCompactProof(rootHash, leaf []byte, leafIdx, leafCount int, proof *Proof, opts ...Option) (bool, error) {
+	if proof == nil {
+		return false, mterr.ErrEmptyNode
+	}
+	if proof.LeafIdx != leafIdx || proof.LeafCount != leafCount {
+		return false, mterr.ErrInvalidProof
+	}
+	return VerifyProof(rootHash, leaf, leafIdx, leafCount, proof.TreeNodes(), opts...)
+}
+
+// MarshalBinary encodes the proof as the format version followed by the leaf index, the tree size, the
+// digest size and the number of siblings as unsigned varints, and the concatenated sibling digests.
+// It implements `encoding.BinaryMarshaler`.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	if p.LeafIdx < 0 || p.LeafCount <= p.LeafIdx {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	size := 0
+	if len(p.Siblings) > 0 {
+		size = len(p.Siblings[0])
+	}
+
+	data := []byte{proofEncodingVersion}
+	data = binary.AppendUvarint(data, uint64(p.LeafIdx))
+	data = binary.AppendUvarint(data, uint64(p.LeafCount))
+	data = binary.AppendUvarint(data, uint64(size))
+	data = binary.AppendUvarint(data, uint64(len(p.Siblings)))
+	for _, sibling := range p.Siblings {
+		if len(sibling) != size {
+			return nil, mterr.ErrInvalidProof
+		}
+		data = append(data, sibling...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a proof encoded by `MarshalBinary`. It implements `encoding.BinaryUnmarshaler`.
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return mterr.ErrInvalidProofEncoding
+	}
+	if data[0] != proofEncodingVersion {
+		return fmt.Errorf("%w: unsupported version %d", mterr.ErrInvalidProofEncoding, data[0])
+	}
+	data = data[1:]
+
+	var fields [4]uint64
+	for idx := range fields {
+		field, n := binary.Uvarint(data)
+		if n <= 0 {
+			return mterr.ErrInvalidProofEncoding
+		}
+		fields[idx], data = field, data[n:]
+	}
+
+	leafIdx, leafCount, size, count := fields[0], fields[1], fields[2], fields[3]
+	switch {
+	case leafCount == 0 || leafCount > math.MaxInt || leafIdx >= leafCount:
+		return mterr.ErrInvalidProofEncoding
+	case count > leafCount || size == 0 && (count != 0 || len(data) != 0):
+		return mterr.ErrInvalidProofEncoding
+	case size > 0 && (uint64(len(data))%size != 0 || uint64(len(data))/size != count):
+		return mterr.ErrInvalidProofEncoding
+	}
+
+	siblings := make([][]byte, count)
+	for idx := range siblings {
+		siblings[idx] = append([]byte(nil), data[:size]...)
+		data = data[size:]
+	}
+
+	p.LeafIdx, p.LeafCount, p.Siblings = int(leafIdx), int(leafCount), siblings
+	return nil
+}
+
+// MarshalText encodes the binary form of the proof in base64, the form the CLI stores proofs in.
+// It implements `encoding.TextMarshaler`, so proofs are embedded into JSON as a string.
+func (p *Proof) MarshalText() ([]byte, error) {
+	data, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.AppendEncode(nil, data), nil
+}
+
+// UnmarshalText decodes a proof encoded by `MarshalText`. It implements `encoding.TextUnmarshaler`.
+func (p *Proof) UnmarshalText(text []byte) error {
+	data, err := base64.StdEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return fmt.Errorf("%w: %v", mterr.ErrInvalidProofEncoding, err)
+	}
+	return p.UnmarshalBinary(data)
+}
+
+// String returns the base64 text form of the proof.
+func (p *Proof) String() string {
+	text, err := p.MarshalText()
+	if err != nil {
+		return fmt.Sprintf("invalid proof: %v", err)
+	}
+	return string(text)
+}
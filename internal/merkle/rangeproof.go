@@ -0,0 +1,87 @@
+package merkle
+
+import (
+	"bytes"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// GenerateRangeProof generates a proof for the contiguous run of leaves `[from, to]`.
+// Every subtree inside the run is recomputed by the verifier from the leaves themselves, so the
+// proof only consists of the siblings on the left boundary path of `from` and on the right boundary
+// path of `to`, i.e. of the roots of all maximal subtrees outside the run, ordered from left to right.
+func (mt *MerkleTree) GenerateRangeProof(from, to int) ([]*TreeNode, error) {
+	if from < 0 || from > to || to >= mt.LeafCount() {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	var result []*TreeNode
+	var walk func(n nodeRef)
+	walk = func(n nodeRef) {
+		switch {
+		case n.r < from || n.l > to:
+			result = append(result, mt.treeNode(n))
+		case n.l < from || n.r > to:
+			left, right := mt.children(n)
+			walk(left)
+			walk(right)
+		}
+	}
+	walk(mt.rootRef())
+	return result, nil
+}
+
+// VerifyRangeProof verifies a range proof without access to the Merkle tree. It checks that `leaves`
+// are the files stored at the indices `from` to `from+len(leaves)-1` in a tree built over `leafCount`
+// files whose root hash is `rootHash`.
+func VerifyRangeProof(rootHash []byte, from int, leaves [][]byte, leafCount int, proofs []*TreeNode, opts ...Option) (bool, error) {
+	to := from + len(leaves) - 1
+	switch {
+	case leafCount <= 0:
+		return false, mterr.ErrEmptyRoot
+	case len(leaves) == 0:
+		return false, mterr.ErrEmptyFile
+	case from < 0 || to >= leafCount:
+		return false, mterr.ErrIndexOutOfBound
+	}
+
+	cfg := newConfig(opts)
+	next := 0
+	var fold func(l, r int) ([]byte, error)
+	fold = func(l, r int) ([]byte, error) {
+		switch {
+		case r < from || l > to:
+			if next >= len(proofs) {
+				return nil, mterr.ErrInvalidProof
+			}
+			proof := proofs[next]
+			next++
+			if proof == nil {
+				return nil, mterr.ErrEmptyNode
+			}
+			return proof.Hash, nil
+		case l == r:
+			return cfg.hashFile(leaves[l-from]), nil
+		}
+
+		mid := cfg.split(l, r)
+		left, err := fold(l, mid)
+		if err != nil {
+			return nil, err
+		}
+		right, err := fold(mid+1, r)
+		if err != nil {
+			return nil, err
+		}
+		return cfg.hashChildren(l, mid, r, left, right), nil
+	}
+
+	merkleHash, err := fold(0, leafCount-1)
+	if err != nil {
+		return false, err
+	}
+	if next != len(proofs) {
+		return false, mterr.ErrInvalidProof
+	}
+	return bytes.Equal(merkleHash, rootHash), nil
+}
@@ -0,0 +1,122 @@
+package merkle
+
+import (
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// Version identifies how interior nodes combine the digests of their children.
+type Version int
+
+const (
+	// VersionHex hashes the concatenated hexadecimal digests of both children.
+	// Every merkle root computed before binary digests were introduced uses this encoding.
+	VersionHex Version = 1
+
+	// VersionBinary hashes the concatenated raw digests of both children like other Merkle tree implementations do.
+	VersionBinary Version = 2
+)
+
+// Scheme records how a Merkle tree is built. The client persists it alongside the merkle root hash
+// and sends it along with the upload, so that the server and the verifier agree on the rules the
+// proofs have to be checked against. The zero value describes the original SHA-256 tree with
+// hexadecimal node encoding, which is what root hash records without a scheme were built with.
+type Scheme struct {
+	HashAlgorithm    string   `json:"hash_algorithm"`
+	DomainSeparation bool     `json:"domain_separation"`
+	Version          Version  `json:"version"`
+	Shape            Shape    `json:"shape"`
+	ChunkSize        int      `json:"chunk_size,omitempty"`
+	Chunking         Chunking `json:"chunking,omitempty"`
+}
+
+// Options converts the scheme into the options to build a tree or to verify a proof with.
+func (s Scheme) Options() ([]Option, error) {
+	h, err := HasherByName(s.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	switch s.Version {
+	case 0, VersionHex, VersionBinary:
+	default:
+		return nil, mterr.ErrUnknownSchemeVersion
+	}
+	switch s.Shape {
+	case 0, ShapeMidpoint, ShapeRFC6962, ShapeMountainRange, ShapeBitcoin:
+	default:
+		return nil, mterr.ErrUnknownTreeShape
+	}
+	if s.ChunkSize < 0 {
+		return nil, mterr.ErrInvalidChunkSize
+	}
+	switch s.Chunking {
+	case 0, ChunkingFixed, ChunkingContentDefined:
+	default:
+		return nil, mterr.ErrUnknownChunking
+	}
+
+	return []Option{
+		WithHasher(h), WithDomainSeparation(s.DomainSeparation), WithVersion(s.Version), WithShape(s.Shape), WithChunkSize(s.ChunkSize), WithChunking(s.Chunking),
+	}, nil
+}
+
+// Equal reports whether both schemes describe the same tree. Empty fields compare equal to their defaults.
+func (s Scheme) Equal(other Scheme) bool {
+	return s.Normalize() == other.Normalize()
+}
+
+// Normalize replaces the empty fields by their defaults, which yields the scheme a tree built with the
+// options of the scheme reports. Schemes are recorded normalized, so that records spell out every field.
+func (s Scheme) Normalize() Scheme {
+	if s.HashAlgorithm == "" {
+		s.HashAlgorithm = SHA256
+	}
+	if s.Version == 0 {
+		s.Version = VersionHex
+	}
+	if s.Shape == 0 {
+		s.Shape = ShapeMidpoint
+	}
+	// The chunking only matters if files are split into chunks
+	if s.ChunkSize == 0 {
+		s.Chunking = 0
+	} else if s.Chunking == 0 {
+		s.Chunking = ChunkingFixed
+	}
+	return s
+}
+
+// Scheme returns the scheme the Merkle tree is built with. The chunking is only reported if files are split into chunks.
+func (mt *MerkleTree) Scheme() Scheme {
+	return mt.cfg.scheme()
+}
+
+// Scheme returns the scheme of the tree the builder computes the root hash of.
+func (b *Builder) Scheme() Scheme {
+	return b.cfg.scheme()
+}
+
+// scheme returns the scheme the options describe.
+func (cfg *config) scheme() Scheme {
+	scheme := Scheme{
+		HashAlgorithm:    cfg.hasher.Name(),
+		DomainSeparation: cfg.domainSeparation,
+		Version:          cfg.version,
+		Shape:            cfg.shape,
+		ChunkSize:        cfg.chunkSize,
+	}
+	if cfg.chunkSize > 0 {
+		scheme.Chunking = cfg.chunking
+	}
+	return scheme
+}
+
+// LeafHash calculates the digest of a file as it is stored in the leaf of a Merkle tree
+// built with the given options.
+func LeafHash(file []byte, opts ...Option) []byte {
+	return newConfig(opts).hashFile(file)
+}
+
+// LeafHash calculates the digest of a file as it is stored in the leaves of this Merkle tree.
+func (mt *MerkleTree) LeafHash(file []byte) []byte {
+	return mt.cfg.hashFile(file)
+}
@@ -0,0 +1,157 @@
+package merkle
+
+import (
+	"math/bits"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// Shape selects how the leaves covered by an interior node are split between its two children.
+type Shape int
+
+const (
+	// ShapeMidpoint splits the leaves `[l, r]` at `l+(r-l)/2` like a segment tree. Every merkle root
+	// computed before the tree shape became configurable uses this split.
+	ShapeMidpoint Shape = 1
+
+	// ShapeRFC6962 puts the largest power of two smaller than the number of leaves into the left
+	// subtree (RFC 6962, section 2.1). Appending a leaf only changes the nodes on the right border
+	// of such a tree, which makes appends O(log n).
+	ShapeRFC6962 Shape = 2
+
+	// ShapeMountainRange stores the tree as a Merkle Mountain Range, whose perfect subtrees, the mountains,
+	// are never modified once complete. Appending a leaf only adds nodes behind the existing ones. The
+	// peaks are bagged from right to left, which yields the same root hash and proofs as `ShapeRFC6962`.
+	ShapeMountainRange Shape = 3
+
+	// ShapeBitcoin duplicates the last node of every level with an odd number of nodes like Bitcoin does,
+	// i.e. it is hashed with itself. The leaves are split like `ShapeRFC6962`, only a right subtree lower
+	// than its left sibling is lifted to the same height by hashing it with itself once per missing level.
+	// Like in Bitcoin, the trees over `[A, B, C]` and `[A, B, C, C]` share their root hash, which is why
+	// proofs are always verified against the recorded number of leaves.
+	ShapeBitcoin Shape = 4
+)
+
+// shapeNames holds the names of the shapes in the order of their values, e.g. to select them on the command line.
+var shapeNames = []struct {
+	shape Shape
+	name  string
+}{
+	{ShapeMidpoint, "midpoint"},
+	{ShapeRFC6962, "rfc6962"},
+	{ShapeMountainRange, "mmr"},
+	{ShapeBitcoin, "bitcoin"},
+}
+
+// ShapeByName returns the shape with the given name, one of `ShapeNames`.
+func ShapeByName(name string) (Shape, error) {
+	for _, s := range shapeNames {
+		if s.name == name {
+			return s.shape, nil
+		}
+	}
+	return 0, mterr.ErrUnknownTreeShape
+}
+
+// ShapeNames returns the names of all tree shapes.
+func ShapeNames() []string {
+	names := make([]string, len(shapeNames))
+	for idx, s := range shapeNames {
+		names[idx] = s.name
+	}
+	return names
+}
+
+// WithShape selects how the leaves are split between the children of the interior nodes.
+// Trees are built with `ShapeMidpoint` by default, which keeps the roots of existing trees verifiable.
+func WithShape(shape Shape) Option {
+	return func(cfg *config) {
+		if shape != 0 {
+			cfg.shape = shape
+		}
+	}
+}
+
+// split returns the index of the last leaf of the left child of the interior node covering the leaves `[l, r]`.
+func (cfg *config) split(l, r int) int {
+	if cfg.powerOfTwoSplit() {
+		return l + 1<<(bits.Len(uint(r-l))-1) - 1
+	}
+	return l + (r-l)/2
+}
+
+// powerOfTwoSplit reports whether the shape puts the largest power of two smaller than the number of leaves
+// into the left subtree, which is the case for every shape but `ShapeMidpoint`.
+func (cfg *config) powerOfTwoSplit() bool {
+	return cfg.appendOnly() || cfg.shape == ShapeBitcoin
+}
+
+// hashChildren calculates the digest of the interior node covering the leaves `[l, r]` from the digests of
+// its children, the left one covering `[l, mid]`. `ShapeBitcoin` trees first lift the right child to the
+// height of the left one by hashing it with itself, which duplicates the last node of the levels in between.
+func (cfg *config) hashChildren(l, mid, r int, left, right []byte) []byte {
+	for lifts := cfg.lifts(l, mid, r); lifts > 0; lifts-- {
+		right = cfg.hashNode(right, right)
+	}
+	return cfg.hashNode(left, right)
+}
+
+// lifts returns the number of times the right child of the interior node covering the leaves `[l, r]` is hashed
+// with itself before it is hashed with its left child covering `[l, mid]`. Only `ShapeBitcoin` trees lift nodes.
+func (cfg *config) lifts(l, mid, r int) int {
+	if cfg.shape != ShapeBitcoin {
+		return 0
+	}
+	return bits.Len(uint(mid-l)) - bits.Len(uint(r-mid-1))
+}
+
+// appendOnly reports whether the shape keeps every subtree once it is complete, which is the case for the
+// `ShapeRFC6962` and the `ShapeMountainRange` shape. Only these trees grow by appending leaves.
+func (cfg *config) appendOnly() bool {
+	return cfg.shape == ShapeRFC6962 || cfg.shape == ShapeMountainRange
+}
+
+// appendLeaf returns the root of the RFC 6962 or Bitcoin shaped tree that results from appending the leaf digest
+// to the tree rooted at `node`. A tree whose size is a power of two becomes the left child of the
+// new root, otherwise the left child is kept and the leaf is appended to the right child. Hence
+// only the O(log n) nodes on the right border are recomputed. They are copied instead of modified,
+// so proofs handed out before the append stay intact.
+func appendLeaf(cfg *config, node *TreeNode, leaf []byte) *TreeNode {
+	idx := node.RightIdx + 1
+	if size := node.RightIdx - node.LeftIdx + 1; size&(size-1) == 0 {
+		right := &TreeNode{Hash: leaf, LeftIdx: idx, RightIdx: idx}
+		return &TreeNode{
+			Hash:     cfg.hashChildren(node.LeftIdx, node.RightIdx, idx, node.Hash, leaf),
+			LeftIdx:  node.LeftIdx,
+			RightIdx: idx,
+			Left:     node,
+			Right:    right,
+		}
+	}
+
+	right := appendLeaf(cfg, node.Right, leaf)
+	return &TreeNode{
+		Hash:     cfg.hashChildren(node.LeftIdx, node.Left.RightIdx, idx, node.Left.Hash, right.Hash),
+		LeftIdx:  node.LeftIdx,
+		RightIdx: idx,
+		Left:     node.Left,
+		Right:    right,
+	}
+}
+
+// leaves returns the leaf digests of the Merkle tree from left to right.
+func (mt *MerkleTree) leaves() [][]byte {
+	leaves := make([][]byte, 0, mt.LeafCount())
+	var walk func(n nodeRef)
+	walk = func(n nodeRef) {
+		if n.l == n.r {
+			leaves = append(leaves, append([]byte(nil), mt.digest(n)...))
+			return
+		}
+		left, right := mt.children(n)
+		walk(left)
+		walk(right)
+	}
+	walk(mt.rootRef())
+	return leaves
+}
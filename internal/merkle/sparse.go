@@ -0,0 +1,323 @@
+package merkle
+
+import (
+	"bytes"
+	"sort"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// SparseMerkleTree is a Merkle tree over the space of all keys, where the key of a file is the hash of its
+// path. Bit `d` of the key selects the child on depth `d`, so every file has a fixed position independent
+// of the other files and the absence of a file can be proven as well as its presence.
+//
+// Spelling out all `2^256` positions is not feasible. As in the Jellyfish Merkle tree, subtrees without
+// any file hash to a placeholder of zero bytes, and a subtree holding a single file is replaced by the
+// leaf of that file. The leaf hash covers the whole key, which pins down the position of the file in
+// the tree. Hence a proof only holds as many siblings as the depth at which the file is the only one in
+// its subtree, which is about `log2(n)` for `n` files.
+type SparseMerkleTree struct {
+	root *sparseNode
+	cfg  *config
+}
+
+// sparseNode is a node of the sparse Merkle tree. Leaves carry the key and the value hash of a file,
+// interior nodes have at least two files below them. Empty subtrees are represented by nil.
+type sparseNode struct {
+	hash        []byte
+	key         []byte
+	valueHash   []byte
+	left, right *sparseNode
+}
+
+// SparseProof proves the presence or the absence of a key in a sparse Merkle tree.
+type SparseProof struct {
+	// Siblings holds the sibling hashes on the path of the key, ordered from the leaf up to the root.
+	Siblings [][]byte
+
+	// LeafKey and LeafValueHash describe the leaf the path of the key ends at. For an inclusion proof
+	// it is the leaf of the key itself. For an exclusion proof it is either the leaf of a different key
+	// sharing the path, or empty if the path ends at an empty subtree.
+	LeafKey       []byte
+	LeafValueHash []byte
+}
+
+// Prefixes prepended to the hashed data of the sparse Merkle tree. Unlike the index-based tree the sparse
+// tree always separates leaves from interior nodes, since its leaves sit at varying depths.
+const (
+	sparseLeafPrefix byte = 0x00
+	sparseNodePrefix byte = 0x01
+)
+
+// BuildSparseMerkleTree builds a sparse Merkle tree over the files keyed by their names.
+// Only the hash algorithm of the options applies, domain separation is always enabled.
+func BuildSparseMerkleTree(names []string, files [][]byte, opts ...Option) (*SparseMerkleTree, error) {
+	switch {
+	case len(files) == 0:
+		return nil, mterr.ErrEmptyFile
+	case len(names) != len(files):
+		return nil, mterr.ErrMissingFileNames
+	}
+
+	smt := &SparseMerkleTree{cfg: newConfig(opts)}
+	leaves := make([]*sparseNode, len(files))
+	for idx, file := range files {
+		leaves[idx] = smt.newLeaf(smt.cfg.hasher.Sum([]byte(names[idx])), smt.cfg.hasher.Sum(file))
+	}
+
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i].key, leaves[j].key) < 0
+	})
+	for idx := 1; idx < len(leaves); idx++ {
+		if bytes.Equal(leaves[idx-1].key, leaves[idx].key) {
+			return nil, mterr.ErrDuplicateFileName
+		}
+	}
+
+	smt.root = smt.build(0, leaves)
+	return smt, nil
+}
+
+// NewSparseMerkleTree returns an empty sparse Merkle tree, which files are inserted into one at a time with `Put`.
+// Only the hash algorithm of the options applies, domain separation is always enabled.
+func NewSparseMerkleTree(opts ...Option) *SparseMerkleTree {
+	return &SparseMerkleTree{cfg: newConfig(opts)}
+}
+
+// SparseKey returns the key of the file with the given name in a sparse Merkle tree built with the given options.
+func SparseKey(name string, opts ...Option) []byte {
+	return newConfig(opts).hasher.Sum([]byte(name))
+}
+
+// RootHash returns the root hash of the sparse Merkle tree.
+func (smt *SparseMerkleTree) RootHash() []byte {
+	return smt.hash(smt.root)
+}
+
+// Put inserts the file with the given name or replaces its content. Only the nodes on the path
+// of the key are recomputed.
+func (smt *SparseMerkleTree) Put(name string, file []byte) {
+	smt.PutValueHash(name, smt.cfg.hasher.Sum(file))
+}
+
+// PutValueHash inserts the file with the given name by the digest of its content, which a `FileHasher`
+// computes while the file is read in pieces.
+func (smt *SparseMerkleTree) PutValueHash(name string, valueHash []byte) {
+	leaf := smt.newLeaf(smt.cfg.hasher.Sum([]byte(name)), valueHash)
+	smt.root = smt.put(smt.root, 0, leaf)
+}
+
+// GenerateProof generates a proof for the file with the given name. The proof shows the presence of
+// the file if `LeafKey` equals its key, otherwise it shows its absence.
+func (smt *SparseMerkleTree) GenerateProof(name string) *SparseProof {
+	key := smt.cfg.hasher.Sum([]byte(name))
+	proof := &SparseProof{}
+
+	curr := smt.root
+	for depth := 0; curr != nil && curr.key == nil; depth++ {
+		if bit(key, depth) {
+			proof.Siblings = append(proof.Siblings, smt.hash(curr.left))
+			curr = curr.right
+		} else {
+			proof.Siblings = append(proof.Siblings, smt.hash(curr.right))
+			curr = curr.left
+		}
+	}
+	if curr != nil {
+		proof.LeafKey, proof.LeafValueHash = curr.key, curr.valueHash
+	}
+
+	for i, j := 0, len(proof.Siblings)-1; i < j; i, j = i+1, j-1 {
+		proof.Siblings[i], proof.Siblings[j] = proof.Siblings[j], proof.Siblings[i]
+	}
+	return proof
+}
+
+// VerifySparseInclusion verifies that `file` is stored under `name` in the sparse Merkle tree with the given root hash.
+func VerifySparseInclusion(rootHash []byte, name string, file []byte, proof *SparseProof, opts ...Option) (bool, error) {
+	if proof == nil {
+		return false, mterr.ErrEmptyNode
+	}
+
+	cfg := newConfig(opts)
+	key := cfg.hasher.Sum([]byte(name))
+	if !bytes.Equal(proof.LeafKey, key) || !bytes.Equal(proof.LeafValueHash, cfg.hasher.Sum(file)) {
+		return false, nil
+	}
+	return verifySparsePath(cfg, rootHash, key, proof)
+}
+
+// VerifySparseExclusion verifies that no file is stored under `name` in the sparse Merkle tree with the given root hash.
+func VerifySparseExclusion(rootHash []byte, name string, proof *SparseProof, opts ...Option) (bool, error) {
+	if proof == nil {
+		return false, mterr.ErrEmptyNode
+	}
+
+	cfg := newConfig(opts)
+	key := cfg.hasher.Sum([]byte(name))
+	if proof.LeafKey != nil {
+		// The path of the key has to end at the leaf of another key sharing the whole path
+		if bytes.Equal(proof.LeafKey, key) || len(proof.LeafKey) != len(key) {
+			return false, nil
+		}
+		for depth := 0; depth < len(proof.Siblings); depth++ {
+			if bit(proof.LeafKey, depth) != bit(key, depth) {
+				return false, nil
+			}
+		}
+	}
+	return verifySparsePath(cfg, rootHash, key, proof)
+}
+
+// UpdateSparseRoot verifies the proof for the file name against the root hash of a sparse Merkle tree and
+// returns the root hash of the tree after `file` is put under the name like `Put` does. For a replaced file
+// the proof has to show the presence of the name, whatever content is stored under it, and for an inserted
+// file its absence. Only the path of the key changes, so the new root hash follows from the proof alone.
+// Proofs that do not match the root hash are refused with `ErrMerkleVerificationFail`.
+func UpdateSparseRoot(rootHash []byte, name string, file []byte, proof *SparseProof, opts ...Option) ([]byte, error) {
+	if proof == nil {
+		return nil, mterr.ErrEmptyNode
+	}
+
+	cfg := newConfig(opts)
+	key := cfg.hasher.Sum([]byte(name))
+	present := bytes.Equal(proof.LeafKey, key)
+	var isVerified bool
+	var err error
+	if present {
+		isVerified, err = verifySparsePath(cfg, rootHash, key, proof)
+	} else {
+		isVerified, err = VerifySparseExclusion(rootHash, name, proof, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isVerified {
+		return nil, mterr.ErrMerkleVerificationFail
+	}
+
+	// A path ending at the leaf of another key now ends at the subtree of both leaves, which split
+	// at the first bit their keys differ in and are padded with empty subtrees above it
+	merkleHash := sparseLeafHash(cfg, key, cfg.hasher.Sum(file))
+	if proof.LeafKey != nil && !present {
+		other := sparseLeafHash(cfg, proof.LeafKey, proof.LeafValueHash)
+		split := len(proof.Siblings)
+		for bit(key, split) == bit(proof.LeafKey, split) {
+			split++
+		}
+		merkleHash = sparseChildHash(cfg, key, split, merkleHash, other)
+
+		empty := make([]byte, cfg.hasher.Size())
+		for depth := split - 1; depth >= len(proof.Siblings); depth-- {
+			merkleHash = sparseChildHash(cfg, key, depth, merkleHash, empty)
+		}
+	}
+	return foldSparsePath(cfg, key, merkleHash, proof.Siblings), nil
+}
+
+// verifySparsePath folds the siblings of the proof into the leaf the proof ends at along the path of the key.
+func verifySparsePath(cfg *config, rootHash, key []byte, proof *SparseProof) (bool, error) {
+	if len(proof.Siblings) > 8*len(key) {
+		return false, mterr.ErrInvalidProof
+	}
+
+	merkleHash := make([]byte, cfg.hasher.Size())
+	if proof.LeafKey != nil {
+		merkleHash = sparseLeafHash(cfg, proof.LeafKey, proof.LeafValueHash)
+	}
+	return bytes.Equal(foldSparsePath(cfg, key, merkleHash, proof.Siblings), rootHash), nil
+}
+
+// foldSparsePath hashes the digest of the node the path of the key ends at with the siblings ordered from
+// the leaf up to the root.
+func foldSparsePath(cfg *config, key, merkleHash []byte, siblings [][]byte) []byte {
+	for idx, sibling := range siblings {
+		merkleHash = sparseChildHash(cfg, key, len(siblings)-1-idx, merkleHash, sibling)
+	}
+	return merkleHash
+}
+
+// sparseChildHash calculates the digest of the node on the given depth whose child on the path of the key
+// has the digest `merkleHash` and whose other child has the digest `sibling`.
+func sparseChildHash(cfg *config, key []byte, depth int, merkleHash, sibling []byte) []byte {
+	if bit(key, depth) {
+		return sparseNodeHash(cfg, sibling, merkleHash)
+	}
+	return sparseNodeHash(cfg, merkleHash, sibling)
+}
+
+// build builds the subtree on the given depth over the leaves sorted by their keys.
+func (smt *SparseMerkleTree) build(depth int, leaves []*sparseNode) *sparseNode {
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		return leaves[0]
+	}
+
+	split := sort.Search(len(leaves), func(i int) bool {
+		return bit(leaves[i].key, depth)
+	})
+	return smt.newNode(smt.build(depth+1, leaves[:split]), smt.build(depth+1, leaves[split:]))
+}
+
+// put returns a copy of the subtree on the given depth with the leaf inserted or replaced.
+func (smt *SparseMerkleTree) put(node *sparseNode, depth int, leaf *sparseNode) *sparseNode {
+	switch {
+	case node == nil:
+		return leaf
+	case node.key != nil && bytes.Equal(node.key, leaf.key):
+		return leaf
+	case node.key != nil:
+		leaves := []*sparseNode{node, leaf}
+		if bytes.Compare(leaf.key, node.key) < 0 {
+			leaves[0], leaves[1] = leaf, node
+		}
+		return smt.build(depth, leaves)
+	case bit(leaf.key, depth):
+		return smt.newNode(node.left, smt.put(node.right, depth+1, leaf))
+	default:
+		return smt.newNode(smt.put(node.left, depth+1, leaf), node.right)
+	}
+}
+
+// newLeaf creates the leaf of the file with the given key and value hash.
+func (smt *SparseMerkleTree) newLeaf(key, valueHash []byte) *sparseNode {
+	return &sparseNode{hash: sparseLeafHash(smt.cfg, key, valueHash), key: key, valueHash: valueHash}
+}
+
+// newNode creates the interior node above the given children.
+func (smt *SparseMerkleTree) newNode(left, right *sparseNode) *sparseNode {
+	return &sparseNode{hash: sparseNodeHash(smt.cfg, smt.hash(left), smt.hash(right)), left: left, right: right}
+}
+
+// hash returns the digest of the given node. Empty subtrees hash to the placeholder of zero bytes.
+func (smt *SparseMerkleTree) hash(node *sparseNode) []byte {
+	if node == nil {
+		return make([]byte, smt.cfg.hasher.Size())
+	}
+	return node.hash
+}
+
+// sparseLeafHash calculates the digest of the leaf with the given key and value hash.
+func sparseLeafHash(cfg *config, key, valueHash []byte) []byte {
+	data := make([]byte, 0, 1+len(key)+len(valueHash))
+	data = append(data, sparseLeafPrefix)
+	data = append(data, key...)
+	data = append(data, valueHash...)
+	return cfg.hasher.Sum(data)
+}
+
+// sparseNodeHash calculates the digest of an interior node from the digests of its children.
+func sparseNodeHash(cfg *config, left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(data, sparseNodePrefix)
+	data = append(data, left...)
+	data = append(data, right...)
+	return cfg.hasher.Sum(data)
+}
+
+// bit returns whether bit `depth` of the key, counted from the most significant bit, is set.
+func bit(key []byte, depth int) bool {
+	return key[depth/8]&(0x80>>(depth%8)) != 0
+}
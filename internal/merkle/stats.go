@@ -0,0 +1,57 @@
+package merkle
+
+import (
+	"math/bits"
+	"time"
+)
+
+// Stats summarizes the size of a Merkle tree and how it was built.
+type Stats struct {
+	LeafCount     int           `json:"leaf_count"`
+	NodeCount     int           `json:"node_count"`
+	Height        int           `json:"height"`         // Number of levels from the root down to the deepest leaf
+	TotalBytes    int           `json:"total_bytes"`    // Size of the digests of all nodes
+	HashAlgorithm string        `json:"hash_algorithm"` // Name of the hash algorithm
+	BuildDuration time.Duration `json:"build_duration"` // Time `BuildMerkleTree` took, zero for decoded trees
+}
+
+// Stats returns the statistics of the Merkle tree. The flat layout and the mountain range always hold
+// `2n-1` digests and their height follows from the leaf count, the pointer layout is walked. The nodes of
+// `ShapeBitcoin` trees hashed from the last node of a level with an odd number of nodes and its duplicate
+// are counted like the nodes they lift it to the height of its left sibling with, see `hashChildren`.
+func (mt *MerkleTree) Stats() Stats {
+	stats := Stats{
+		LeafCount:     mt.LeafCount(),
+		HashAlgorithm: mt.cfg.hasher.Name(),
+		BuildDuration: mt.buildDuration,
+	}
+
+	switch {
+	case mt.flat != nil:
+		stats.NodeCount = 2*mt.flat.leafCount - 1
+		stats.Height = len(mt.flat.levels)
+	case mt.mmr != nil:
+		stats.NodeCount = 2*mt.mmr.leafCount - 1
+		stats.Height = bits.Len(uint(mt.mmr.leafCount-1)) + 1
+	case mt.cfg.shape == ShapeBitcoin:
+		stats.NodeCount, stats.Height = mt.cfg.liftedStats(mt.root)
+	default:
+		stats.NodeCount = countNodes(mt.root)
+		stats.Height = maxDepth(mt.root)
+	}
+	stats.TotalBytes = stats.NodeCount * mt.cfg.hasher.Size()
+	return stats
+}
+
+// liftedStats returns the number of nodes and the height of the subtree rooted at `node` including the nodes
+// its right children are lifted with.
+func (cfg *config) liftedStats(node *TreeNode) (nodeCount, height int) {
+	if node.Left == nil {
+		return 1, 1
+	}
+
+	leftCount, leftHeight := cfg.liftedStats(node.Left)
+	rightCount, rightHeight := cfg.liftedStats(node.Right)
+	lifts := cfg.lifts(node.LeftIdx, node.Left.RightIdx, node.RightIdx)
+	return 1 + leftCount + rightCount + lifts, 1 + max(leftHeight, rightHeight+lifts)
+}
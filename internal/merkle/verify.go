@@ -0,0 +1,97 @@
+package merkle
+
+import (
+	"bytes"
+
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+)
+
+// VerifyProof verifies a Merkle proof without access to the Merkle tree.
+// It checks that `leaf` is the file stored at `leafIdx` in a tree built over `leafCount` files
+// whose root hash is `rootHash`. The proof path must contain the sibling hashes ordered from the
+// leaf up to the root, as returned by `GenerateMerkleProof`. Whether each sibling sits to the left
+// or to the right is derived from `leafIdx` and `leafCount` alone, so the caller only has to trust
+// the root hash and the leaf count it persisted at upload time. The options must match the ones
+// the tree was built with, e.g. the scheme recorded alongside the root hash.
+func VerifyProof(rootHash, leaf []byte, leafIdx, leafCount int, proofs []*TreeNode, opts ...Option) (bool, error) {
+	switch {
+	case leafCount <= 0:
+		return false, mterr.ErrEmptyRoot
+	case leafIdx < 0 || leafIdx >= leafCount:
+		return false, mterr.ErrIndexOutOfBound
+	}
+
+	cfg := newConfig(opts)
+	merkleHash, err := cfg.rootHash(cfg.hashFile(leaf), leafIdx, leafCount, proofs)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(merkleHash, rootHash), nil
+}
+
+// VerifyUpdateProof verifies the replacement of the file at `leafIdx` without access to the Merkle tree.
+// The same proof path has to lead from the digest of the replaced leaf `oldLeafHash` to the old root hash
+// and from the new file `newLeaf` to the new root hash. Since all siblings are shared, this shows that
+// no other file of the tree was changed along with it.
+func VerifyUpdateProof(oldRootHash, newRootHash, oldLeafHash, newLeaf []byte, leafIdx, leafCount int, proofs []*TreeNode, opts ...Option) (bool, error) {
+	switch {
+	case leafCount <= 0:
+		return false, mterr.ErrEmptyRoot
+	case leafIdx < 0 || leafIdx >= leafCount:
+		return false, mterr.ErrIndexOutOfBound
+	}
+
+	cfg := newConfig(opts)
+	oldMerkleHash, err := cfg.rootHash(oldLeafHash, leafIdx, leafCount, proofs)
+	if err != nil {
+		return false, err
+	}
+	newMerkleHash, err := cfg.rootHash(cfg.hashFile(newLeaf), leafIdx, leafCount, proofs)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(oldMerkleHash, oldRootHash) && bytes.Equal(newMerkleHash, newRootHash), nil
+}
+
+// rootHash folds the proof path into the leaf digest and returns the resulting root hash.
+func (cfg *config) rootHash(leafHash []byte, leafIdx, leafCount int, proofs []*TreeNode) ([]byte, error) {
+	path := cfg.proofPath(leafIdx, leafCount)
+	if len(proofs) != len(path) {
+		return nil, mterr.ErrInvalidProof
+	}
+
+	merkleHash := leafHash
+	for idx, proof := range proofs {
+		if proof == nil {
+			return nil, mterr.ErrEmptyNode
+		}
+
+		// Siblings are consumed bottom-up whereas the path is recorded top-down
+		n := path[len(path)-1-idx]
+		mid := cfg.split(n.l, n.r)
+		if leafIdx <= mid {
+			merkleHash = cfg.hashChildren(n.l, mid, n.r, merkleHash, proof.Hash)
+		} else {
+			merkleHash = cfg.hashChildren(n.l, mid, n.r, proof.Hash, merkleHash)
+		}
+	}
+	return merkleHash, nil
+}
+
+// proofPath walks from the root down to the leaf at `leafIdx` in a tree of `leafCount` leaves
+// and records the interior nodes on the way. Whether the leaf lies in the left or the right subtree
+// of each of them follows from their split, which mirrors `buildTree`.
+func (cfg *config) proofPath(leafIdx, leafCount int) []nodeRef {
+	var path []nodeRef
+	l, r := 0, leafCount-1
+	for l < r {
+		path = append(path, nodeRef{l: l, r: r, depth: len(path)})
+		mid := cfg.split(l, r)
+		if leafIdx <= mid {
+			r = mid
+		} else {
+			l = mid + 1
+		}
+	}
+	return path
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bytes"
+
+	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
+)
+
+// chunkStore holds the chunks of all files content addressed by `mt.ChunkKey`, so a chunk shared by
+// several files or by consecutive uploads of near-identical files is only stored once. Every chunk
+// counts the files consisting of it and is dropped together with the last of them.
+type chunkStore struct {
+	chunks map[string]*storedChunk
+}
+
+// storedChunk is a chunk together with the number of files referencing it.
+type storedChunk struct {
+	data []byte
+	refs int
+}
+
+// manifest lists the keys of the chunks a file consists of in their order.
+type manifest []string
+
+// put stores the chunks of the file and returns its manifest.
+func (cs *chunkStore) put(chunks [][]byte) manifest {
+	if cs.chunks == nil {
+		cs.chunks = make(map[string]*storedChunk)
+	}
+
+	m := make(manifest, len(chunks))
+	for idx, chunk := range chunks {
+		key := mt.ChunkKey(chunk)
+		stored, ok := cs.chunks[key]
+		if !ok {
+			stored = &storedChunk{data: bytes.Clone(chunk)}
+			cs.chunks[key] = stored
+		}
+		stored.refs++
+		m[idx] = key
+	}
+	return m
+}
+
+// release drops the reference of a file to its chunks.
+func (cs *chunkStore) release(m manifest) {
+	for _, key := range m {
+		stored := cs.chunks[key]
+		if stored.refs--; stored.refs == 0 {
+			delete(cs.chunks, key)
+		}
+	}
+}
+
+// get returns the chunk stored under the key.
+func (cs *chunkStore) get(key string) ([]byte, bool) {
+	stored, ok := cs.chunks[key]
+	if !ok {
+		return nil, false
+	}
+	return stored.data, true
+}
+
+// file joins the chunks of the manifest into the file. A file of a single chunk shares its memory.
+func (cs *chunkStore) file(m manifest) []byte {
+	if len(m) == 1 {
+		return cs.chunks[m[0]].data
+	}
+
+	size := 0
+	for _, key := range m {
+		size += len(cs.chunks[key].data)
+	}
+	file := make([]byte, 0, size)
+	for _, key := range m {
+		file = append(file, cs.chunks[key].data...)
+	}
+	return file
+}
+
+// missing returns the keys of the chunks not stored yet, each key only once.
+func (cs *chunkStore) missing(keys []string) []string {
+	var missing []string
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if _, ok := cs.chunks[key]; !ok && !seen[key] {
+			missing = append(missing, key)
+		}
+		seen[key] = true
+	}
+	return missing
+}
@@ -1,10 +1,15 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"sync"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
@@ -18,8 +23,31 @@ import (
 type grpcServer struct {
 	api.UnimplementedMerkleTreeServer
 
-	files      [][]byte
+	// mu guards the files and the merkle trees, which uploads and appends modify. Files are stored
+	// as manifests of their chunks in the content addressed chunk store
+	mu         sync.RWMutex
+	files      []manifest
+	chunks     chunkStore
 	merkleTree *mt.MerkleTree
+
+	// chunkTrees caches the sub-trees over the chunks of the files by their indices, which are built on the first
+	// chunk download of a file. Modifying a file drops its sub-tree. chunkTreesMu guards the cache, as it is
+	// filled while `mu` is only held for reading
+	chunkTreesMu sync.Mutex
+	chunkTrees   map[int]*mt.MerkleTree
+
+	// fileIdxs and sparseTree key the files by their names, and fileNames holds the name of every file by its index.
+	// All of them are nil if the files were uploaded without names
+	fileIdxs   map[string]int
+	fileNames  []string
+	sparseTree *mt.SparseMerkleTree
+
+	// debug prints the whole merkle tree to stdout after every modification, which floods the logs for large trees
+	debug bool
+
+	// stateDir is the directory the files and the merkle trees are persisted to after every modification and
+	// loaded from on startup, see `state.go`. Nothing is persisted if it is empty
+	stateDir string
 }
 
 func RunServer() {
@@ -54,41 +82,251 @@ func RunServer() {
 // newgrpcServer: creates a grpc server and registers the service to that server
 func NewgrpcServer() (*grpc.Server, error) {
 	gsrv := grpc.NewServer()
-	srv := &grpcServer{}
+	debug, _ := strconv.ParseBool(os.Getenv("DEBUG"))
+	srv := &grpcServer{debug: debug, stateDir: os.Getenv("STATE_DIR")}
+	if srv.stateDir != "" {
+		if err := srv.loadState(); err != nil {
+			return nil, err
+		}
+	}
 	api.RegisterMerkleTreeServer(gsrv, srv)
 	return gsrv, nil
 }
 
 func (s *grpcServer) Upload(ctx context.Context, req *api.UploadRequest) (
 	*api.UploadResponse, error) {
-	merkleTree, err := mt.BuildMerkleTree(req.Files)
+	return s.upload(req.FileNames, req.Files, req.Scheme)
+}
+
+// GetMissingChunks returns the keys of the chunks the server does not store yet, which lets the client
+// only upload those along with the manifests of its files.
+func (s *grpcServer) GetMissingChunks(ctx context.Context, req *api.MissingChunksRequest) (
+	*api.MissingChunksResponse, error) {
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &api.MissingChunksResponse{ChunkKeys: s.chunks.missing(req.ChunkKeys)}, nil
+}
+
+// UploadChunks uploads the files given as manifests of their chunks. Every chunk has to be either stored
+// on the server already or sent along, in which case its key is computed from its content rather than
+// trusted. The files are then stored and the merkle tree is built just like on `Upload`.
+func (s *grpcServer) UploadChunks(ctx context.Context, req *api.UploadChunksRequest) (
+	*api.UploadResponse, error) {
+
+	sent := make(map[string][]byte, len(req.Chunks))
+	for _, chunk := range req.Chunks {
+		sent[mt.ChunkKey(chunk)] = chunk
+	}
+
+	files := make([][]byte, len(req.Manifests))
+	s.mu.RLock()
+	for idx, m := range req.Manifests {
+		for _, key := range m.ChunkKeys {
+			chunk, ok := sent[key]
+			if !ok {
+				if chunk, ok = s.chunks.get(key); !ok {
+					s.mu.RUnlock()
+					return nil, mterr.ErrChunkNotFound
+				}
+			}
+			files[idx] = append(files[idx], chunk...)
+		}
+	}
+	s.mu.RUnlock()
+
+	util.ServerLog(fmt.Sprintf("received %d of the chunks of %d files", len(req.Chunks), len(files)))
+	return s.upload(req.FileNames, files, req.Scheme)
+}
+
+// upload replaces the stored files by the given ones and builds the merkle trees over them.
+func (s *grpcServer) upload(names []string, files [][]byte, scheme *api.Scheme) (
+	*api.UploadResponse, error) {
+	opts, err := util.ToMerkleScheme(scheme).Options()
 	if err != nil {
 		return nil, err
 	}
-	s.files = req.Files
+
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileIdxs map[string]int
+	var fileNames []string
+	var sparseTree *mt.SparseMerkleTree
+	if len(names) != 0 {
+		if sparseTree, err = mt.BuildSparseMerkleTree(names, files, opts...); err != nil {
+			return nil, err
+		}
+		fileIdxs = make(map[string]int, len(names))
+		for idx, name := range names {
+			fileIdxs[name] = idx
+		}
+		fileNames = append([]string(nil), names...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Chunks shared with the replaced files are kept, as they are referenced again before they are released
+	manifests := make([]manifest, len(files))
+	for idx, file := range files {
+		manifests[idx] = s.chunks.put(mt.Chunks(file, opts...))
+	}
+	return s.replaceFiles(manifests, merkleTree, fileIdxs, fileNames, sparseTree)
+}
+
+// UploadStream uploads the files streamed one after another in pieces, which replace the stored files like on
+// `Upload`. Only the file currently streamed is held in memory: every completed file is hashed into its leaf
+// and put into the chunk store right away, and the merkle tree is built over the leaf digests at the end.
+func (s *grpcServer) UploadStream(stream api.MerkleTree_UploadStreamServer) error {
+	var (
+		opts       []mt.Option
+		scheme     *api.Scheme
+		leaves     [][]byte
+		manifests  []manifest
+		fileIdxs   map[string]int
+		fileNames  []string
+		sparseTree *mt.SparseMerkleTree
+		name       string
+		file       *mt.FileHasher // Hashes the file currently received, nil between two files
+		fileChunks manifest       // Chunks of the file currently received stored so far
+	)
+
+	// The chunks of the files received so far are released again if the upload does not complete
+	abort := func(err error) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, m := range append(manifests, fileChunks) {
+			s.chunks.release(m)
+		}
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return abort(err)
+		}
+
+		if scheme == nil {
+			scheme = req.Scheme
+			if opts, err = util.ToMerkleScheme(scheme).Options(); err != nil {
+				return abort(err)
+			}
+			if req.FileName != "" {
+				fileIdxs = make(map[string]int)
+				sparseTree = mt.NewSparseMerkleTree(opts...)
+			}
+		}
+
+		if file == nil {
+			name = req.FileName
+			switch _, exists := fileIdxs[name]; {
+			case (sparseTree != nil) != (name != ""):
+				return abort(mterr.ErrMissingFileNames)
+			case exists:
+				return abort(mterr.ErrDuplicateFileName)
+			}
+
+			// Every chunk is stored as soon as it is complete, so only the chunk in progress is buffered
+			file = mt.NewFileHasher(func(chunk []byte) {
+				s.mu.Lock()
+				fileChunks = append(fileChunks, s.chunks.put([][]byte{chunk})...)
+				s.mu.Unlock()
+			}, opts...)
+		}
+		file.Write(req.Content)
+		if !req.EndOfFile {
+			continue
+		}
+
+		leaf, valueHash := file.Finish()
+		leaves = append(leaves, leaf)
+		manifests = append(manifests, fileChunks)
+		if sparseTree != nil {
+			fileIdxs[name] = len(fileNames)
+			fileNames = append(fileNames, name)
+			sparseTree.PutValueHash(name, valueHash)
+		}
+		file, fileChunks = nil, nil
+	}
+
+	// A file without its last piece is incomplete
+	if file != nil {
+		return abort(mterr.ErrEmptyFile)
+	}
+
+	merkleTree, err := mt.BuildMerkleTreeFromLeaves(leaves, opts...)
+	if err != nil {
+		return abort(err)
+	}
+	util.ServerLog(fmt.Sprintf("received %d files in a stream", len(leaves)))
+
+	s.mu.Lock()
+	resp, err := s.replaceFiles(manifests, merkleTree, fileIdxs, fileNames, sparseTree)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// replaceFiles replaces the stored files by the ones whose chunks were put into the chunk store already,
+// together with the merkle trees built over them, and persists them. The caller has to hold `s.mu`.
+func (s *grpcServer) replaceFiles(manifests []manifest, merkleTree *mt.MerkleTree, fileIdxs map[string]int, fileNames []string, sparseTree *mt.SparseMerkleTree) (*api.UploadResponse, error) {
+	for _, m := range s.files {
+		s.chunks.release(m)
+	}
+	s.files = manifests
+	s.dropChunkTree(-1)
 	s.merkleTree = merkleTree
-	merkleRoot := merkleTree.GetMerkleRoot()
+	s.fileIdxs = fileIdxs
+	s.fileNames = fileNames
+	s.sparseTree = sparseTree
 
-	util.ServerLog("Resulting merkle tree after the client uploaded all the files")
-	merkleTree.PrintTreeInfo()
-	return &api.UploadResponse{MerkleRootHash: []byte(merkleRoot.Hash)}, nil
+	fileIdxsToSave := make([]int, len(manifests))
+	for idx := range manifests {
+		fileIdxsToSave[idx] = idx
+	}
+	if err := s.saveState(fileIdxsToSave...); err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if s.debug {
+		util.ServerLog("Resulting merkle tree after the client uploaded all the files")
+		merkleTree.PrintTreeInfo()
+	}
+	return &api.UploadResponse{
+		MerkleRootHash: []byte(mt.EncodeHash(merkleTree.GetMerkleRoot().Hash)),
+		Scheme:         util.ToAPIScheme(merkleTree.Scheme()),
+		SparseRootHash: s.sparseRootHash(),
+	}, nil
 }
 
 func (s *grpcServer) Download(ctx context.Context, req *api.DownloadRequest) (
 	*api.DownloadResponse, error) {
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	fileIdx := int(req.FileIndex)
 	if fileIdx < 0 || fileIdx >= len(s.files) {
 		return nil, mterr.ErrIndexOutOfBound
 	}
 
-	return &api.DownloadResponse{FileContent: s.files[fileIdx]}, nil
+	return &api.DownloadResponse{FileContent: s.file(fileIdx)}, nil
 }
 
 func (s *grpcServer) GetMerkleProof(ctx context.Context, req *api.MerkleProofRequest) (
 	*api.MerkleProofResponse, error) {
 
 	util.ServerLog("running GetMerkleProof ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	fileIdx := int(req.FileIndex)
 	if fileIdx < 0 || fileIdx >= len(s.files) {
 		return nil, mterr.ErrIndexOutOfBound
@@ -102,74 +340,610 @@ func (s *grpcServer) GetMerkleProof(ctx context.Context, req *api.MerkleProofReq
 
 	proofs := make([]*api.TreeNode, len(merkleProofs))
 	for idx, proof := range merkleProofs {
-		apiProof := &api.TreeNode{
-			Hash:     proof.Hash,
-			LeftIdx:  int64(proof.LeftIdx),
-			RightIdx: int64(proof.RightIdx),
-		}
+		apiProof := toAPINode(proof)
 		if proof.Left != nil {
-			apiProof.Left = &api.TreeNode{
-				Hash:     proof.Left.Hash,
-				LeftIdx:  int64(proof.Left.LeftIdx),
-				RightIdx: int64(proof.Left.RightIdx),
-			}
+			apiProof.Left = toAPINode(proof.Left)
 		}
 		if proof.Right != nil {
-			apiProof.Right = &api.TreeNode{
-				Hash:     proof.Right.Hash,
-				LeftIdx:  int64(proof.Right.LeftIdx),
-				RightIdx: int64(proof.Right.RightIdx),
-			}
+			apiProof.Right = toAPINode(proof.Right)
 		}
 		proofs[idx] = apiProof
 	}
 
-	return &api.MerkleProofResponse{Proofs: proofs}, nil
+	return &api.MerkleProofResponse{
+		Proofs: proofs,
+		Scheme: util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
 }
 
 func (s *grpcServer) VerifyMerkleProof(ctx context.Context, req *api.VerifyProofRequest) (
 	*api.VerifyProofResponse, error) {
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	fileIdx := int(req.FileIndex)
 	if fileIdx < 0 || fileIdx >= len(s.files) {
 		return nil, mterr.ErrIndexOutOfBound
 	}
 
-	file := s.files[fileIdx]
-	if string(req.FileHash) != mt.CalcHash(file) {
+	if !util.ToMerkleScheme(req.Scheme).Equal(s.merkleTree.Scheme()) {
+		return nil, mterr.ErrSchemeMisMatch
+	}
+
+	rootHash, err := mt.DecodeHash(string(req.RootHash))
+	if err != nil {
+		return nil, err
+	}
+
+	fileHash, err := mt.DecodeHash(string(req.FileHash))
+	if err != nil {
+		return nil, err
+	}
+
+	file := s.file(fileIdx)
+	if !bytes.Equal(fileHash, s.merkleTree.LeafHash(file)) {
 		return nil, mterr.ErrFileHashMisMatch
 	}
 
 	merkleProofs := make([]*mt.TreeNode, len(req.Proofs))
 	for idx, proof := range req.Proofs {
-		merkleProof := &mt.TreeNode{
-			Hash:     proof.Hash,
-			LeftIdx:  int(proof.LeftIdx),
-			RightIdx: int(proof.RightIdx),
+		merkleProof, err := toMerkleNode(proof)
+		if err != nil {
+			return nil, err
 		}
 
 		if proof.Left != nil {
-			merkleProof.Left = &mt.TreeNode{
-				Hash:     proof.Left.Hash,
-				LeftIdx:  int(proof.Left.LeftIdx),
-				RightIdx: int(proof.Left.RightIdx),
+			if merkleProof.Left, err = toMerkleNode(proof.Left); err != nil {
+				return nil, err
 			}
 		}
 
 		if proof.Right != nil {
-			merkleProof.Right = &mt.TreeNode{
-				Hash:     proof.Right.Hash,
-				LeftIdx:  int(proof.Right.LeftIdx),
-				RightIdx: int(proof.Right.RightIdx),
+			if merkleProof.Right, err = toMerkleNode(proof.Right); err != nil {
+				return nil, err
 			}
 		}
 
 		merkleProofs[idx] = merkleProof
 	}
 
-	isVerified, err := s.merkleTree.VerifyMerkleProof(string(req.RootHash), string(req.FileHash), fileIdx, merkleProofs)
+	isVerified, err := s.merkleTree.VerifyMerkleProof(rootHash, fileHash, fileIdx, merkleProofs)
 	if err != nil {
 		return nil, err
 	}
 	return &api.VerifyProofResponse{IsVerified: isVerified}, nil
 }
+
+// DownloadRange streams the files `[from_index, to_index]` in order followed by their range proof,
+// which only consists of the siblings on the two boundary paths of the range.
+func (s *grpcServer) DownloadRange(req *api.DownloadRangeRequest, stream api.MerkleTree_DownloadRangeServer) error {
+	util.ServerLog("running DownloadRange ")
+
+	// Take a snapshot under the lock, so that slow clients do not block uploads and appends
+	s.mu.RLock()
+	from, to := int(req.FromIndex), int(req.ToIndex)
+	if from < 0 || from > to || to >= len(s.files) {
+		s.mu.RUnlock()
+		return mterr.ErrIndexOutOfBound
+	}
+
+	files := make([][]byte, 0, to-from+1)
+	for idx := from; idx <= to; idx++ {
+		files = append(files, s.file(idx))
+	}
+	rangeProofs, err := s.merkleTree.GenerateRangeProof(from, to)
+	leafCount := s.merkleTree.LeafCount()
+	scheme := s.merkleTree.Scheme()
+	s.mu.RUnlock()
+	if err != nil {
+		util.ErrLog(err.Error())
+		return err
+	}
+
+	for _, file := range files {
+		err := stream.Send(&api.DownloadRangeResponse{
+			Payload: &api.DownloadRangeResponse_FileContent{FileContent: file},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	proofs := make([]*api.TreeNode, len(rangeProofs))
+	for idx, proof := range rangeProofs {
+		proofs[idx] = toAPINode(proof)
+	}
+
+	return stream.Send(&api.DownloadRangeResponse{
+		Payload: &api.DownloadRangeResponse_RangeProof{
+			RangeProof: &api.RangeProof{
+				Proofs:    proofs,
+				LeafCount: int64(leafCount),
+				Scheme:    util.ToAPIScheme(scheme),
+			},
+		},
+	})
+}
+
+// GetMultiProof generates a single merkle proof for several files. Siblings shared by the proof paths
+// of the files are only included once.
+func (s *grpcServer) GetMultiProof(ctx context.Context, req *api.MultiProofRequest) (
+	*api.MultiProofResponse, error) {
+
+	util.ServerLog("running GetMultiProof ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.merkleTree == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	fileIdxs := make([]int, len(req.FileIndices))
+	for idx, fileIdx := range req.FileIndices {
+		fileIdxs[idx] = int(fileIdx)
+	}
+
+	multiProofs, err := s.merkleTree.GenerateMultiProof(fileIdxs)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs := make([]*api.TreeNode, len(multiProofs))
+	for idx, proof := range multiProofs {
+		proofs[idx] = toAPINode(proof)
+	}
+
+	return &api.MultiProofResponse{
+		Proofs: proofs,
+		Scheme: util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
+}
+
+// AppendFiles appends the files to the uploaded ones. Only the nodes of the merkle tree affected by
+// the new leaves are recomputed, so the client can ingest files continuously without re-uploading.
+// The files are only appended to the tree the client recorded, whose peaks are returned so that the
+// client can recompute the new merkle root hash itself. Trees with the midpoint shape have no peaks
+// and are refused.
+func (s *grpcServer) AppendFiles(ctx context.Context, req *api.AppendFilesRequest) (
+	*api.AppendFilesResponse, error) {
+
+	if len(req.Files) == 0 {
+		return nil, mterr.ErrEmptyFile
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.merkleTree == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	if !util.ToMerkleScheme(req.Scheme).Equal(s.merkleTree.Scheme()) {
+		return nil, mterr.ErrSchemeMisMatch
+	}
+	if int(req.OldLeafCount) != s.merkleTree.LeafCount() {
+		return nil, mterr.ErrLeafCountMisMatch
+	}
+	if string(req.OldMerkleRootHash) != mt.EncodeHash(s.merkleTree.GetMerkleRoot().Hash) {
+		return nil, mterr.ErrMerkleRootHashMisMatch
+	}
+
+	peaks, err := s.merkleTree.Peaks()
+	if err != nil {
+		return nil, err
+	}
+	oldPeaks := make([][]byte, len(peaks))
+	for idx, peak := range peaks {
+		oldPeaks[idx] = []byte(mt.EncodeHash(peak))
+	}
+
+	// Files uploaded with names stay keyed, so every appended file needs a new and unique name
+	if s.sparseTree != nil {
+		if len(req.FileNames) != len(req.Files) {
+			return nil, mterr.ErrMissingFileNames
+		}
+		names := make(map[string]bool, len(req.FileNames))
+		for _, name := range req.FileNames {
+			if _, ok := s.fileIdxs[name]; ok || names[name] {
+				return nil, mterr.ErrDuplicateFileName
+			}
+			names[name] = true
+		}
+	}
+
+	opts, err := s.merkleTree.Scheme().Options()
+	if err != nil {
+		return nil, err
+	}
+
+	// The files are appended at once, so that the peaks of a mountain range are only rebagged once
+	if err := s.merkleTree.Append(req.Files...); err != nil {
+		return nil, err
+	}
+	var sparseProofs []*api.SparseProof
+	appendedIdxs := make([]int, len(req.Files))
+	for idx, file := range req.Files {
+		appendedIdxs[idx] = len(s.files)
+		if s.sparseTree != nil {
+			s.fileIdxs[req.FileNames[idx]] = len(s.files)
+			s.fileNames = append(s.fileNames, req.FileNames[idx])
+			sparseProofs = append(sparseProofs, toAPISparseProof(s.sparseTree.GenerateProof(req.FileNames[idx])))
+			s.sparseTree.Put(req.FileNames[idx], file)
+		}
+		s.files = append(s.files, s.chunks.put(mt.Chunks(file, opts...)))
+	}
+	if err := s.saveState(appendedIdxs...); err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if s.debug {
+		util.ServerLog("Resulting merkle tree after the client appended the files")
+		s.merkleTree.PrintTreeInfo()
+	}
+	return &api.AppendFilesResponse{
+		MerkleRootHash: []byte(mt.EncodeHash(s.merkleTree.GetMerkleRoot().Hash)),
+		LeafCount:      int64(s.merkleTree.LeafCount()),
+		Scheme:         util.ToAPIScheme(s.merkleTree.Scheme()),
+		SparseRootHash: s.sparseRootHash(),
+		OldPeaks:       oldPeaks,
+		SparseProofs:   sparseProofs,
+	}, nil
+}
+
+// GetConsistencyProof proves that the current merkle tree extends the tree the client recorded
+// when it held `old_leaf_count` files, i.e. that files were only appended since (RFC 9162).
+func (s *grpcServer) GetConsistencyProof(ctx context.Context, req *api.ConsistencyProofRequest) (
+	*api.ConsistencyProofResponse, error) {
+
+	util.ServerLog("running GetConsistencyProof ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.merkleTree == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	leafCount := s.merkleTree.LeafCount()
+	consistencyProofs, err := s.merkleTree.GenerateConsistencyProof(int(req.OldLeafCount), leafCount)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs := make([]*api.TreeNode, len(consistencyProofs))
+	for idx, proof := range consistencyProofs {
+		proofs[idx] = toAPINode(proof)
+	}
+
+	return &api.ConsistencyProofResponse{
+		Proofs:         proofs,
+		MerkleRootHash: []byte(mt.EncodeHash(s.merkleTree.GetMerkleRoot().Hash)),
+		LeafCount:      int64(leafCount),
+		Scheme:         util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
+}
+
+// ReplaceFile replaces the file at the given index and only recomputes the path from its leaf to the root.
+// The response carries the old and the new merkle root hash, the leaf hash of the replaced file and the
+// proof path of the leaf, which lets the client verify that nothing but this file changed. Keyed files are
+// only replaced if the request names the file stored at the index.
+func (s *grpcServer) ReplaceFile(ctx context.Context, req *api.ReplaceFileRequest) (
+	*api.ReplaceFileResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fileIdx := int(req.FileIndex)
+	if fileIdx < 0 || fileIdx >= len(s.files) {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	if !util.ToMerkleScheme(req.Scheme).Equal(s.merkleTree.Scheme()) {
+		return nil, mterr.ErrSchemeMisMatch
+	}
+
+	// Keyed files are only replaced if the client names the file stored at the index
+	switch {
+	case s.sparseTree == nil && req.FileName != "":
+		return nil, mterr.ErrNotKeyed
+	case s.sparseTree != nil && req.FileName != s.fileNames[fileIdx]:
+		return nil, fmt.Errorf("%w: %q is not stored at index %d", mterr.ErrFileNameNotFound, req.FileName, fileIdx)
+	}
+
+	oldRootHash := s.merkleTree.GetMerkleRoot().Hash
+	oldFileHash := s.merkleTree.LeafHash(s.file(fileIdx))
+	if err := s.merkleTree.UpdateLeaf(fileIdx, req.FileContent); err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	opts, err := s.merkleTree.Scheme().Options()
+	if err != nil {
+		return nil, err
+	}
+	m := s.chunks.put(mt.Chunks(req.FileContent, opts...))
+	s.chunks.release(s.files[fileIdx])
+	s.files[fileIdx] = m
+	s.dropChunkTree(fileIdx)
+	var sparseProof *api.SparseProof
+	if s.sparseTree != nil {
+		sparseProof = toAPISparseProof(s.sparseTree.GenerateProof(req.FileName))
+		s.sparseTree.Put(req.FileName, req.FileContent)
+	}
+
+	merkleProofs, err := s.merkleTree.GenerateMerkleProof(fileIdx)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	proofs := make([]*api.TreeNode, len(merkleProofs))
+	for idx, proof := range merkleProofs {
+		proofs[idx] = toAPINode(proof)
+	}
+
+	if err := s.saveState(fileIdx); err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	if s.debug {
+		util.ServerLog("Resulting merkle tree after the client replaced a file")
+		s.merkleTree.PrintTreeInfo()
+	}
+	return &api.ReplaceFileResponse{
+		OldMerkleRootHash: []byte(mt.EncodeHash(oldRootHash)),
+		NewMerkleRootHash: []byte(mt.EncodeHash(s.merkleTree.GetMerkleRoot().Hash)),
+		OldFileHash:       []byte(mt.EncodeHash(oldFileHash)),
+		Proofs:            proofs,
+		LeafCount:         int64(s.merkleTree.LeafCount()),
+		Scheme:            util.ToAPIScheme(s.merkleTree.Scheme()),
+		NewSparseRootHash: s.sparseRootHash(),
+		FileName:          req.FileName,
+		SparseProof:       sparseProof,
+	}, nil
+}
+
+// DownloadChunk returns a single chunk of a file together with its proof path in the sub-tree of the file
+// and the proof path of the file, which link the chunk to the merkle root without the rest of the file.
+func (s *grpcServer) DownloadChunk(ctx context.Context, req *api.DownloadChunkRequest) (
+	*api.DownloadChunkResponse, error) {
+
+	util.ServerLog("running DownloadChunk ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fileIdx := int(req.FileIndex)
+	if fileIdx < 0 || fileIdx >= len(s.files) {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	scheme := s.merkleTree.Scheme()
+	opts, err := scheme.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	m, chunkIdx := s.files[fileIdx], int(req.ChunkIndex)
+	if chunkIdx < 0 || chunkIdx >= len(m) {
+		return nil, mterr.ErrIndexOutOfBound
+	}
+
+	chunk, _ := s.chunks.get(m[chunkIdx])
+	chunkTree, err := s.chunkTree(fileIdx, opts...)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+	chunkProofs, err := chunkTree.GenerateMerkleProof(chunkIdx)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	fileProofs, err := s.merkleTree.GenerateMerkleProof(fileIdx)
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &api.DownloadChunkResponse{
+		ChunkContent: chunk,
+		ChunkCount:   int64(len(m)),
+		ChunkProofs:  toAPINodes(chunkProofs),
+		FileProofs:   toAPINodes(fileProofs),
+		LeafCount:    int64(s.merkleTree.LeafCount()),
+		Scheme:       util.ToAPIScheme(scheme),
+	}, nil
+}
+
+// chunkTree returns the sub-tree over the chunks of the file at the index, which is built from the chunks
+// of its manifest on the first call. The caller has to hold `s.mu` at least for reading.
+func (s *grpcServer) chunkTree(fileIdx int, opts ...mt.Option) (*mt.MerkleTree, error) {
+	s.chunkTreesMu.Lock()
+	defer s.chunkTreesMu.Unlock()
+	if chunkTree, ok := s.chunkTrees[fileIdx]; ok {
+		return chunkTree, nil
+	}
+
+	m := s.files[fileIdx]
+	leaves := make([][]byte, len(m))
+	for idx, key := range m {
+		chunk, _ := s.chunks.get(key)
+		leaves[idx] = mt.ChunkLeafHash(chunk, opts...)
+	}
+	chunkTree, err := mt.BuildChunkTree(leaves, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.chunkTrees == nil {
+		s.chunkTrees = make(map[int]*mt.MerkleTree)
+	}
+	s.chunkTrees[fileIdx] = chunkTree
+	return chunkTree, nil
+}
+
+// dropChunkTree drops the cached sub-tree of the file at the index, or of all files for a negative index.
+// The caller has to hold `s.mu` for writing.
+func (s *grpcServer) dropChunkTree(fileIdx int) {
+	s.chunkTreesMu.Lock()
+	defer s.chunkTreesMu.Unlock()
+	if fileIdx < 0 {
+		s.chunkTrees = nil
+		return
+	}
+	delete(s.chunkTrees, fileIdx)
+}
+
+// GetByKey returns the file stored under the given file name together with its proof of inclusion
+// in the sparse merkle tree, which lets the client verify the file without knowing its index.
+func (s *grpcServer) GetByKey(ctx context.Context, req *api.KeyRequest) (
+	*api.GetByKeyResponse, error) {
+
+	util.ServerLog("running GetByKey ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.sparseTree == nil {
+		return nil, mterr.ErrNotKeyed
+	}
+
+	fileIdx, ok := s.fileIdxs[req.FileName]
+	if !ok {
+		return nil, mterr.ErrFileNameNotFound
+	}
+
+	return &api.GetByKeyResponse{
+		FileContent: s.file(fileIdx),
+		FileIndex:   int64(fileIdx),
+		Proof:       toAPISparseProof(s.sparseTree.GenerateProof(req.FileName)),
+		Scheme:      util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
+}
+
+// ProveAbsent proves that no file is stored under the given file name.
+func (s *grpcServer) ProveAbsent(ctx context.Context, req *api.KeyRequest) (
+	*api.ProveAbsentResponse, error) {
+
+	util.ServerLog("running ProveAbsent ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.sparseTree == nil {
+		return nil, mterr.ErrNotKeyed
+	}
+
+	if _, ok := s.fileIdxs[req.FileName]; ok {
+		return nil, mterr.ErrFileNameExists
+	}
+
+	return &api.ProveAbsentResponse{
+		Proof:  toAPISparseProof(s.sparseTree.GenerateProof(req.FileName)),
+		Scheme: util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
+}
+
+// GetNodes returns the nodes on the requested positions of a level of the merkle tree. Clients walk the tree
+// level by level from the root with it, and only descend into the nodes differing from their local tree.
+func (s *grpcServer) GetNodes(ctx context.Context, req *api.NodesRequest) (
+	*api.NodesResponse, error) {
+
+	util.ServerLog("running GetNodes ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.merkleTree == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	nodes, err := s.merkleTree.GetNodes(int(req.Level), int(req.FromIndex), int(req.ToIndex))
+	if err != nil {
+		util.ErrLog(err.Error())
+		return nil, err
+	}
+
+	return &api.NodesResponse{
+		Nodes:     toAPINodes(nodes),
+		LeafCount: int64(s.merkleTree.LeafCount()),
+		Scheme:    util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
+}
+
+// GetTreeInfo returns the statistics of the merkle tree without printing the tree itself.
+func (s *grpcServer) GetTreeInfo(ctx context.Context, req *api.TreeInfoRequest) (
+	*api.TreeInfoResponse, error) {
+
+	util.ServerLog("running GetTreeInfo ")
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.merkleTree == nil {
+		return nil, mterr.ErrEmptyRoot
+	}
+
+	stats := s.merkleTree.Stats()
+	return &api.TreeInfoResponse{
+		MerkleRootHash:  []byte(mt.EncodeHash(s.merkleTree.GetMerkleRoot().Hash)),
+		LeafCount:       int64(stats.LeafCount),
+		NodeCount:       int64(stats.NodeCount),
+		Height:          int64(stats.Height),
+		TotalBytes:      int64(stats.TotalBytes),
+		HashAlgorithm:   stats.HashAlgorithm,
+		BuildDurationNs: stats.BuildDuration.Nanoseconds(),
+		Scheme:          util.ToAPIScheme(s.merkleTree.Scheme()),
+	}, nil
+}
+
+// file returns the content of the file at the index.
+func (s *grpcServer) file(fileIdx int) []byte {
+	return s.chunks.file(s.files[fileIdx])
+}
+
+// sparseRootHash returns the hexadecimal root hash of the sparse merkle tree, or nil if the files are not keyed.
+func (s *grpcServer) sparseRootHash() []byte {
+	if s.sparseTree == nil {
+		return nil
+	}
+	return []byte(mt.EncodeHash(s.sparseTree.RootHash()))
+}
+
+// toAPINode converts a merkle tree node without its children to its gRPC representation.
+// Digests travel as hexadecimal strings over gRPC.
+func toAPINode(node *mt.TreeNode) *api.TreeNode {
+	return &api.TreeNode{
+		Hash:     mt.EncodeHash(node.Hash),
+		LeftIdx:  int64(node.LeftIdx),
+		RightIdx: int64(node.RightIdx),
+	}
+}
+
+// toAPISparseProof converts a sparse merkle proof to its gRPC representation.
+func toAPISparseProof(proof *mt.SparseProof) *api.SparseProof {
+	siblings := make([]string, len(proof.Siblings))
+	for idx, sibling := range proof.Siblings {
+		siblings[idx] = mt.EncodeHash(sibling)
+	}
+
+	apiProof := &api.SparseProof{Siblings: siblings}
+	if proof.LeafKey != nil {
+		apiProof.LeafKey = mt.EncodeHash(proof.LeafKey)
+		apiProof.LeafValueHash = mt.EncodeHash(proof.LeafValueHash)
+	}
+	return apiProof
+}
+
+// toAPINodes converts merkle proof nodes without their children to their gRPC representation.
+func toAPINodes(nodes []*mt.TreeNode) []*api.TreeNode {
+	apiNodes := make([]*api.TreeNode, len(nodes))
+	for idx, node := range nodes {
+		apiNodes[idx] = toAPINode(node)
+	}
+	return apiNodes
+}
+
+// toMerkleNode converts a tree node received over gRPC without its children.
+func toMerkleNode(node *api.TreeNode) (*mt.TreeNode, error) {
+	hash, err := mt.DecodeHash(node.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return &mt.TreeNode{
+		Hash:     hash,
+		LeftIdx:  int(node.LeftIdx),
+		RightIdx: int(node.RightIdx),
+	}, nil
+}
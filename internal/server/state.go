@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+	"github.com/srinathln7/merkle_gaurd/lib/util"
+)
+
+// Files the state of the server is persisted to in its state directory. The merkle tree is stored in its
+// binary encoding, so that a restarted server loads it without hashing a single file.
+const (
+	stateTreeFile  = "merkle.tree" // Encoded merkle tree, written last on every modification
+	stateNamesFile = "names.json"  // Names of the files by their indices, only present if the files are keyed
+	stateFilesDir  = "files"       // Contents of the files, one file per index
+)
+
+// saveState persists the files at the given indices together with the names of all files and the merkle tree
+// to the state directory. Files at other indices are left untouched, so only the modified ones are written.
+// Without a state directory nothing is persisted. The caller has to hold `s.mu`.
+func (s *grpcServer) saveState(fileIdxs ...int) error {
+	if s.stateDir == "" {
+		return nil
+	}
+
+	filesDir := filepath.Join(s.stateDir, stateFilesDir)
+	if err := os.MkdirAll(filesDir, 0755); err != nil {
+		return err
+	}
+	for _, fileIdx := range fileIdxs {
+		file := s.file(fileIdx)
+		err := writeFileAtomic(filepath.Join(filesDir, strconv.Itoa(fileIdx)), func(w io.Writer) error {
+			_, err := w.Write(file)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	namesFile := filepath.Join(s.stateDir, stateNamesFile)
+	if s.fileNames == nil {
+		if err := os.Remove(namesFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	} else {
+		err := writeFileAtomic(namesFile, func(w io.Writer) error {
+			return json.NewEncoder(w).Encode(s.fileNames)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeFileAtomic(filepath.Join(s.stateDir, stateTreeFile), func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		if _, err := s.merkleTree.WriteTo(bw); err != nil {
+			return err
+		}
+		return bw.Flush()
+	})
+}
+
+// loadState restores the files and the merkle trees a server persisted to the state directory before.
+// The merkle tree is decoded rather than rebuilt, only the sparse merkle tree is rebuilt from the names.
+// A state directory without a merkle tree leaves the server empty.
+func (s *grpcServer) loadState() error {
+	treeFile, err := os.Open(filepath.Join(s.stateDir, stateTreeFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer treeFile.Close()
+
+	merkleTree, err := mt.ReadMerkleTree(bufio.NewReader(treeFile))
+	if err != nil {
+		return err
+	}
+	opts, err := merkleTree.Scheme().Options()
+	if err != nil {
+		return err
+	}
+
+	var fileNames []string
+	data, err := os.ReadFile(filepath.Join(s.stateDir, stateNamesFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &fileNames); err != nil {
+			return err
+		}
+		if len(fileNames) != merkleTree.LeafCount() {
+			return fmt.Errorf("%w: %d file names for %d files", mterr.ErrLeafCountMisMatch, len(fileNames), merkleTree.LeafCount())
+		}
+	}
+
+	var fileIdxs map[string]int
+	var sparseTree *mt.SparseMerkleTree
+	if fileNames != nil {
+		fileIdxs = make(map[string]int, len(fileNames))
+		sparseTree = mt.NewSparseMerkleTree(opts...)
+	}
+
+	manifests := make([]manifest, 0, merkleTree.LeafCount())
+	for fileIdx := 0; fileIdx < merkleTree.LeafCount(); fileIdx++ {
+		file, err := os.ReadFile(filepath.Join(s.stateDir, stateFilesDir, strconv.Itoa(fileIdx)))
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, s.chunks.put(mt.Chunks(file, opts...)))
+		if sparseTree != nil {
+			fileIdxs[fileNames[fileIdx]] = fileIdx
+			sparseTree.Put(fileNames[fileIdx], file)
+		}
+	}
+
+	s.files = manifests
+	s.merkleTree = merkleTree
+	s.fileIdxs = fileIdxs
+	s.fileNames = fileNames
+	s.sparseTree = sparseTree
+	util.ServerLog(fmt.Sprintf("loaded %d files and the merkle tree from %s", len(manifests), s.stateDir))
+	return nil
+}
+
+// writeFileAtomic writes a file through a temporary file in the same directory, which replaces the file once
+// it is completely written. A crash therefore leaves either the old or the new file behind.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -1,10 +1,17 @@
 package test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/srinathln7/merkle_gaurd/internal/client"
 	"github.com/srinathln7/merkle_gaurd/internal/server"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -12,6 +19,8 @@ import (
 
 	api "github.com/srinathln7/merkle_gaurd/api/v1/proto"
 	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
+	"github.com/srinathln7/merkle_gaurd/lib/util"
 )
 
 // SetupGRPCClient: sets up the grpc client
@@ -145,3 +154,1060 @@ func testClientMerkleRootMisMatch(t *testing.T, grpcClient api.MerkleTreeClient)
 	require.NoError(t, err)
 	require.NotEqual(t, expectedResp.MerkleRootHash, uploadResp.MerkleRootHash)
 }
+
+// forgedRootClient: mimics a malicious server that reports a merkle root hash of its own choosing on upload
+type forgedRootClient struct {
+	api.MerkleTreeClient
+	forgedRootHash []byte
+}
+
+func (c *forgedRootClient) Upload(ctx context.Context, in *api.UploadRequest, opts ...grpc.CallOption) (*api.UploadResponse, error) {
+	if _, err := c.MerkleTreeClient.Upload(ctx, in, opts...); err != nil {
+		return nil, err
+	}
+	return &api.UploadResponse{MerkleRootHash: c.forgedRootHash}, nil
+}
+
+func testClientUploadLocalMerkleRoot(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"),
+	}
+
+	uploadResp, err := client.Upload(grpcClient, files, mt.Scheme{})
+	require.NoError(t, err)
+	require.Equal(t, "50a504831bd50fee3581d287168a85a8dcdd6aa777ffd0fe35e37290268a0153", uploadResp.RootHash)
+	require.Equal(t, len(files), uploadResp.LeafCount)
+
+	// The client must refuse a merkle root hash that does not match the files it uploaded
+	_, err = client.Upload(&forgedRootClient{
+		MerkleTreeClient: grpcClient,
+		forgedRootHash:   []byte("50a504831bd50fee3581d287168a85a8dcdd6aa777ffd0fe35e37290268a0155"),
+	}, files, mt.Scheme{})
+	require.ErrorIs(t, err, mterr.ErrMerkleRootHashMisMatch)
+}
+
+func testClientMerkleVerificationWithScheme(t *testing.T, grpcClient api.MerkleTreeClient) {
+	ctx := context.Background()
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"),
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA3_256, DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+	require.Equal(t, scheme, uploadResp.Scheme)
+
+	for fileIdx, file := range files {
+		proofResp, err := client.GetMerkleProof(grpcClient, fileIdx)
+		require.NoError(t, err)
+		require.Equal(t, scheme, proofResp.Scheme)
+
+		verifyReq := client.VerifyRequest{
+			RootHash:  []byte(uploadResp.RootHash),
+			LeafCount: uploadResp.LeafCount,
+			FileIdx:   fileIdx,
+			File:      file,
+			Proofs:    proofResp.Proofs,
+			Scheme:    scheme,
+		}
+
+		_, err = client.VerifyMerkleProofLocally(verifyReq)
+		require.NoError(t, err)
+
+		_, err = client.VerifyMerkleProof(grpcClient, verifyReq)
+		require.NoError(t, err)
+	}
+
+	// The server refuses to verify a proof under a different scheme
+	proofResp, err := grpcClient.GetMerkleProof(ctx, &api.MerkleProofRequest{FileIndex: 0})
+	require.NoError(t, err)
+	_, err = grpcClient.VerifyMerkleProof(
+		ctx,
+		&api.VerifyProofRequest{
+			RootHash:  []byte(uploadResp.RootHash),
+			FileHash:  []byte(mt.CalcHash(files[0])),
+			FileIndex: 0,
+			Proofs:    proofResp.Proofs,
+		},
+	)
+	require.Error(t, err)
+}
+
+func testClientAppendFiles(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"),
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	uploadResp, err := client.Upload(grpcClient, files[:3], scheme)
+	require.NoError(t, err)
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+
+	// The server refuses to append under a different scheme
+	_, err = client.AppendFiles(grpcClient, nil, files[3:], &client.RootRecord{LeafCount: 3, Scheme: mt.Scheme{}})
+	require.Error(t, err)
+
+	appendResp, err := client.AppendFiles(grpcClient, nil, files[3:], record)
+	require.NoError(t, err)
+	require.Equal(t, len(files), appendResp.LeafCount)
+	require.True(t, scheme.Equal(appendResp.Scheme))
+
+	// The new root equals the root of the tree built over all files at once
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+	require.Equal(t, mt.EncodeHash(merkleTree.GetMerkleRoot().Hash), appendResp.RootHash)
+
+	for fileIdx, file := range files {
+		downloadResp, err := client.Download(grpcClient, fileIdx)
+		require.NoError(t, err)
+		require.Equal(t, file, downloadResp.File)
+
+		proofResp, err := client.GetMerkleProof(grpcClient, fileIdx)
+		require.NoError(t, err)
+
+		_, err = client.VerifyMerkleProofLocally(client.VerifyRequest{
+			RootHash:  []byte(appendResp.RootHash),
+			LeafCount: appendResp.LeafCount,
+			FileIdx:   fileIdx,
+			File:      file,
+			Proofs:    proofResp.Proofs,
+			Scheme:    appendResp.Scheme,
+		})
+		require.NoError(t, err)
+	}
+
+	// The server refuses to append to a tree other than the recorded one
+	_, err = client.AppendFiles(grpcClient, nil, [][]byte{[]byte("F")}, record)
+	require.ErrorContains(t, err, mterr.ErrLeafCountMisMatch.Error())
+	forged := *record
+	forged.LeafCount = len(files)
+	_, err = client.AppendFiles(grpcClient, nil, [][]byte{[]byte("F")}, &forged)
+	require.ErrorContains(t, err, mterr.ErrMerkleRootHashMisMatch.Error())
+
+	// The client refuses a root hash or peaks that do not match the recorded tree and the appended files
+	record = &client.RootRecord{RootHash: appendResp.RootHash, LeafCount: appendResp.LeafCount, Scheme: appendResp.Scheme}
+	_, err = client.AppendFiles(&forgedAppendClient{
+		MerkleTreeClient: grpcClient,
+		forge: func(resp *api.AppendFilesResponse) {
+			resp.MerkleRootHash = []byte(record.RootHash)
+		},
+	}, nil, [][]byte{[]byte("F")}, record)
+	require.ErrorIs(t, err, mterr.ErrMerkleRootHashMisMatch)
+
+	// The server appended the file before the client refused its response
+	require.NoError(t, merkleTree.Append([]byte("F")))
+	_, err = client.AppendFiles(&forgedAppendClient{
+		MerkleTreeClient: grpcClient,
+		forge: func(resp *api.AppendFilesResponse) {
+			resp.OldPeaks[0] = resp.OldPeaks[len(resp.OldPeaks)-1]
+		},
+	}, nil, [][]byte{[]byte("G")}, &client.RootRecord{RootHash: mt.EncodeHash(merkleTree.GetMerkleRoot().Hash), LeafCount: len(files) + 1, Scheme: scheme})
+	require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+
+	// Trees with the midpoint shape have no peaks to append to
+	uploadResp, err = client.Upload(grpcClient, files[:3], mt.Scheme{})
+	require.NoError(t, err)
+	_, err = client.AppendFiles(grpcClient, nil, files[3:], &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme})
+	require.ErrorContains(t, err, mterr.ErrUnsupportedShape.Error())
+}
+
+// forgedAppendClient: mimics a malicious server that alters its response after appending the files
+type forgedAppendClient struct {
+	api.MerkleTreeClient
+	forge func(resp *api.AppendFilesResponse)
+}
+
+func (c *forgedAppendClient) AppendFiles(ctx context.Context, in *api.AppendFilesRequest, opts ...grpc.CallOption) (*api.AppendFilesResponse, error) {
+	resp, err := c.MerkleTreeClient.AppendFiles(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.forge(resp)
+	return resp, nil
+}
+
+func testClientConsistencyProof(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"), []byte("F"), []byte("G"),
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	uploadResp, err := client.Upload(grpcClient, files[:3], scheme)
+	require.NoError(t, err)
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+
+	_, err = grpcClient.AppendFiles(context.Background(), &api.AppendFilesRequest{
+		Files:             files[3:],
+		Scheme:            util.ToAPIScheme(scheme),
+		OldMerkleRootHash: []byte(record.RootHash),
+		OldLeafCount:      int64(record.LeafCount),
+	})
+	require.NoError(t, err)
+
+	// The grown tree is consistent with the recorded one. Schemes recorded with empty fields are returned normalized
+	consistencyResp, err := client.VerifyConsistency(grpcClient, &client.RootRecord{RootHash: record.RootHash, LeafCount: record.LeafCount, Scheme: scheme})
+	require.NoError(t, err)
+	require.Equal(t, len(files), consistencyResp.LeafCount)
+	require.Equal(t, uploadResp.Scheme, consistencyResp.Scheme)
+	require.Equal(t, mt.SHA256, consistencyResp.Scheme.HashAlgorithm)
+
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+	require.Equal(t, mt.EncodeHash(merkleTree.GetMerkleRoot().Hash), consistencyResp.RootHash)
+
+	// A server that rewrote the first files is caught
+	rewritten := append([][]byte{[]byte("X")}, files[1:]...)
+	_, err = client.Upload(grpcClient, rewritten, scheme)
+	require.NoError(t, err)
+	_, err = client.VerifyConsistency(grpcClient, record)
+	require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+}
+
+func testClientReplaceFile(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"),
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: scheme}
+
+	replaceResp, err := client.ReplaceFile(grpcClient, 2, "", []byte("X"), record)
+	require.NoError(t, err)
+	require.Equal(t, len(files), replaceResp.LeafCount)
+	require.Equal(t, uploadResp.Scheme, replaceResp.Scheme)
+
+	// The new root equals the root of the tree built over the updated files
+	files[2] = []byte("X")
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+	require.Equal(t, mt.EncodeHash(merkleTree.GetMerkleRoot().Hash), replaceResp.RootHash)
+
+	downloadResp, err := client.Download(grpcClient, 2)
+	require.NoError(t, err)
+	require.Equal(t, files[2], downloadResp.File)
+
+	// A stale record is refused since the server no longer holds the recorded root
+	_, err = client.ReplaceFile(grpcClient, 3, "", []byte("Y"), record)
+	require.ErrorIs(t, err, mterr.ErrMerkleRootHashMisMatch)
+
+	_, err = client.ReplaceFile(grpcClient, len(files), "", []byte("Y"), record)
+	require.Error(t, err)
+}
+
+func testClientMultiProof(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := make([][]byte, 24)
+	for idx := range files {
+		files[idx] = []byte{byte('A' + idx)}
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+
+	fileIdxs, err := util.ParseIndices("0,3,10-20", uploadResp.LeafCount)
+	require.NoError(t, err)
+	require.Len(t, fileIdxs, 13)
+
+	proofResp, err := client.GetMultiProof(grpcClient, fileIdxs)
+	require.NoError(t, err)
+
+	verifyReq := client.MultiVerifyRequest{
+		RootHash:  []byte(uploadResp.RootHash),
+		LeafCount: uploadResp.LeafCount,
+		FileIdxs:  fileIdxs,
+		Files:     make([][]byte, len(fileIdxs)),
+		Proofs:    proofResp.Proofs,
+		Scheme:    proofResp.Scheme,
+	}
+	for idx, fileIdx := range fileIdxs {
+		verifyReq.Files[idx] = files[fileIdx]
+	}
+
+	_, err = client.VerifyMultiProofLocally(verifyReq)
+	require.NoError(t, err)
+
+	// A tampered file is detected
+	verifyReq.Files[0] = []byte("X")
+	_, err = client.VerifyMultiProofLocally(verifyReq)
+	require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+
+	_, err = client.GetMultiProof(grpcClient, []int{0, len(files)})
+	require.Error(t, err)
+
+	_, err = util.ParseIndices("0,3-", uploadResp.LeafCount)
+	require.ErrorIs(t, err, mterr.ErrInvalidIndices)
+
+	// Indices are checked against the number of files, or a fixed maximum if it is unknown, before ranges are expanded
+	_, err = util.ParseIndices(fmt.Sprintf("0,%d", len(files)), uploadResp.LeafCount)
+	require.ErrorIs(t, err, mterr.ErrInvalidIndices)
+	_, err = util.ParseIndices("0-9223372036854775807", uploadResp.LeafCount)
+	require.ErrorIs(t, err, mterr.ErrInvalidIndices)
+	_, err = util.ParseIndices("0-9223372036854775807", 0)
+	require.ErrorIs(t, err, mterr.ErrInvalidIndices)
+	_, err = util.ParseIndices("0-9223372036854775808", 0)
+	require.ErrorIs(t, err, mterr.ErrInvalidIndices)
+
+	fileIdxs, err = util.ParseIndices(fmt.Sprintf("0-%d", util.MaxIndices-2), 0)
+	require.NoError(t, err)
+	require.Len(t, fileIdxs, util.MaxIndices-1)
+	fileIdxs, err = util.ParseIndices(fmt.Sprintf("0-%d,7", util.MaxIndices-2), 0)
+	require.NoError(t, err)
+	require.Len(t, fileIdxs, util.MaxIndices)
+	_, err = util.ParseIndices(fmt.Sprintf("0-%d,7,8", util.MaxIndices-2), 0)
+	require.ErrorIs(t, err, mterr.ErrInvalidIndices)
+}
+
+func testClientDownloadRange(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := make([][]byte, 24)
+	for idx := range files {
+		files[idx] = []byte{byte('A' + idx)}
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+
+	for _, r := range [][2]int{{0, 0}, {5, 17}, {10, 23}, {0, 23}} {
+		downloadResp, err := client.DownloadRange(grpcClient, r[0], r[1], record)
+		require.NoError(t, err)
+		require.Equal(t, files[r[0]:r[1]+1], downloadResp.Files)
+	}
+
+	// The batch is refused against a root record of different files
+	forged := *record
+	forged.RootHash = mt.EncodeHash(mt.LeafHash([]byte("X")))
+	_, err = client.DownloadRange(grpcClient, 5, 17, &forged)
+	require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+
+	_, err = client.DownloadRange(grpcClient, 17, 5, record)
+	require.Error(t, err)
+}
+
+func testClientKeyedFiles(t *testing.T, grpcClient api.MerkleTreeClient) {
+	names := []string{"a.txt", "b.txt", "c.txt", "docs/d.txt"}
+	files := [][]byte{[]byte("A"), []byte("B"), []byte("C"), []byte("D")}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	uploadResp, err := client.UploadNamed(grpcClient, names, files, scheme)
+	require.NoError(t, err)
+	require.NotEmpty(t, uploadResp.SparseRootHash)
+	record := &client.RootRecord{
+		RootHash:       uploadResp.RootHash,
+		LeafCount:      uploadResp.LeafCount,
+		SparseRootHash: uploadResp.SparseRootHash,
+		Scheme:         uploadResp.Scheme,
+	}
+
+	for fileIdx, name := range names {
+		getResp, err := client.GetByKey(grpcClient, name, record)
+		require.NoError(t, err)
+		require.Equal(t, fileIdx, getResp.FileIdx)
+		require.Equal(t, files[fileIdx], getResp.File)
+
+		_, err = client.ProveAbsent(grpcClient, name, record)
+		require.Error(t, err)
+	}
+
+	for _, name := range []string{"e.txt", "A", "docs", ""} {
+		_, err := client.ProveAbsent(grpcClient, name, record)
+		require.NoError(t, err)
+
+		_, err = client.GetByKey(grpcClient, name, record)
+		require.Error(t, err)
+	}
+
+	// Proofs are refused against the sparse root of different files
+	forged := *record
+	forged.SparseRootHash = record.RootHash
+	_, err = client.GetByKey(grpcClient, "a.txt", &forged)
+	require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+	_, err = client.ProveAbsent(grpcClient, "e.txt", &forged)
+	require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+
+	// Files uploaded without names cannot be fetched by name
+	forged.SparseRootHash = ""
+	_, err = client.GetByKey(grpcClient, "a.txt", &forged)
+	require.ErrorIs(t, err, mterr.ErrNotKeyed)
+
+	// Appended files need unique names and become reachable by name
+	_, err = client.AppendFiles(grpcClient, nil, [][]byte{[]byte("E")}, record)
+	require.Error(t, err)
+	_, err = client.AppendFiles(grpcClient, []string{"a.txt"}, [][]byte{[]byte("E")}, record)
+	require.Error(t, err)
+
+	appendResp, err := client.AppendFiles(grpcClient, []string{"e.txt"}, [][]byte{[]byte("E")}, record)
+	require.NoError(t, err)
+	record = &client.RootRecord{
+		RootHash:       appendResp.RootHash,
+		LeafCount:      appendResp.LeafCount,
+		SparseRootHash: appendResp.SparseRootHash,
+		Scheme:         appendResp.Scheme,
+	}
+
+	getResp, err := client.GetByKey(grpcClient, "e.txt", record)
+	require.NoError(t, err)
+	require.Equal(t, 4, getResp.FileIdx)
+	require.Equal(t, []byte("E"), getResp.File)
+
+	// Replacing a file keeps its name
+	replaceResp, err := client.ReplaceFile(grpcClient, 1, "b.txt", []byte("B2"), record)
+	require.NoError(t, err)
+	record.RootHash, record.SparseRootHash = replaceResp.RootHash, replaceResp.SparseRootHash
+
+	getResp, err = client.GetByKey(grpcClient, "b.txt", record)
+	require.NoError(t, err)
+	require.Equal(t, []byte("B2"), getResp.File)
+
+	// Keyed files are only replaced under the name of the file at the index, which the server checks
+	_, err = client.ReplaceFile(grpcClient, 1, "a.txt", []byte("A2"), record)
+	require.ErrorContains(t, err, mterr.ErrFileNameNotFound.Error())
+	_, err = client.ReplaceFile(grpcClient, 1, "", []byte("B3"), record)
+	require.ErrorIs(t, err, mterr.ErrMissingFileNames)
+	_, err = client.ReplaceFile(grpcClient, 1, "b.txt", []byte("B3"), &client.RootRecord{RootHash: record.RootHash, LeafCount: record.LeafCount, Scheme: record.Scheme})
+	require.ErrorIs(t, err, mterr.ErrNotKeyed)
+	getResp, err = client.GetByKey(grpcClient, "a.txt", record)
+	require.NoError(t, err)
+	require.Equal(t, []byte("A"), getResp.File)
+
+	// The client refuses sparse root hashes that do not match the proofs of the replaced and appended files
+	_, err = client.ReplaceFile(&forgedReplaceClient{
+		MerkleTreeClient: grpcClient,
+		forge: func(resp *api.ReplaceFileResponse) {
+			resp.NewSparseRootHash = []byte(record.RootHash)
+		},
+	}, 1, "b.txt", []byte("B2"), record)
+	require.ErrorIs(t, err, mterr.ErrMerkleRootHashMisMatch)
+	_, err = client.ReplaceFile(&forgedReplaceClient{
+		MerkleTreeClient: grpcClient,
+		forge: func(resp *api.ReplaceFileResponse) {
+			resp.FileName = "f.txt"
+		},
+	}, 1, "b.txt", []byte("B2"), record)
+	require.ErrorIs(t, err, mterr.ErrFileNameNotFound)
+	_, err = client.ReplaceFile(&forgedReplaceClient{
+		MerkleTreeClient: grpcClient,
+		forge: func(resp *api.ReplaceFileResponse) {
+			resp.FileName = "a.txt"
+		},
+	}, 1, "b.txt", []byte("B2"), record)
+	require.ErrorIs(t, err, mterr.ErrFileNameNotFound)
+
+	_, err = client.AppendFiles(&forgedAppendClient{
+		MerkleTreeClient: grpcClient,
+		forge: func(resp *api.AppendFilesResponse) {
+			resp.SparseRootHash = []byte(record.SparseRootHash)
+		},
+	}, []string{"f.txt"}, [][]byte{[]byte("F")}, record)
+	require.ErrorIs(t, err, mterr.ErrMerkleRootHashMisMatch)
+
+	// Files are keyed by their slash separated path relative to the uploaded directory, wherever it is uploaded from
+	dir := t.TempDir()
+	for _, tc := range []struct{ dir, filePath, key string }{
+		{dir, filepath.Join(dir, "a.txt"), "a.txt"},
+		{dir, filepath.Join(dir, "docs", "d.txt"), "docs/d.txt"},
+		{dir + string(filepath.Separator), filepath.Join(dir, ".", "docs", "..", "a.txt"), "a.txt"},
+		{"uploads", filepath.Join("uploads", "docs", "d.txt"), "docs/d.txt"},
+		{"", "a.txt", "a.txt"},
+	} {
+		key, err := util.FileKey(tc.dir, tc.filePath)
+		require.NoError(t, err)
+		require.Equal(t, tc.key, key)
+	}
+	for _, filePath := range []string{dir, filepath.Join(dir, ".."), filepath.Join(dir, "..", "a.txt"), "a.txt"} {
+		_, err := util.FileKey(dir, filePath)
+		require.ErrorIs(t, err, mterr.ErrInvalidFileName)
+	}
+}
+
+// forgedReplaceClient: mimics a malicious server that alters its response after replacing the file
+type forgedReplaceClient struct {
+	api.MerkleTreeClient
+	forge func(resp *api.ReplaceFileResponse)
+}
+
+func (c *forgedReplaceClient) ReplaceFile(ctx context.Context, in *api.ReplaceFileRequest, opts ...grpc.CallOption) (*api.ReplaceFileResponse, error) {
+	resp, err := c.MerkleTreeClient.ReplaceFile(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.forge(resp)
+	return resp, nil
+}
+
+func testClientRootHash(t *testing.T, grpcClient api.MerkleTreeClient) {
+	dir := t.TempDir()
+	var files [][]byte
+	for idx := 0; idx < 11; idx++ {
+		file := []byte(fmt.Sprintf("file content %d", idx))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%02d.txt", idx)), file, 0644))
+		files = append(files, file)
+	}
+
+	// Files that cannot be read are skipped by listing and reading the directory alike
+	require.NoError(t, os.Symlink(filepath.Join(dir, "missing.txt"), filepath.Join(dir, "dangling.txt")))
+
+	filePaths, err := util.ListFilesFromDir(dir)
+	require.NoError(t, err)
+	require.Len(t, filePaths, len(files))
+	names, dirFiles, err := util.ReadNamedFilesFromDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, files, dirFiles)
+	for idx, name := range names {
+		require.Equal(t, filepath.Join(dir, name), filePaths[idx])
+	}
+
+	schemes := []mt.Scheme{
+		{},
+		{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962},
+	}
+	for _, scheme := range schemes {
+		uploadResp, err := client.Upload(grpcClient, files, scheme)
+		require.NoError(t, err)
+
+		// The streamed root matches the root of the uploaded files
+		rootHashResp, err := client.RootHash(filePaths, scheme)
+		require.NoError(t, err)
+		require.Equal(t, uploadResp.RootHash, rootHashResp.RootHash)
+		require.Equal(t, uploadResp.LeafCount, rootHashResp.LeafCount)
+		require.Equal(t, uploadResp.Scheme, rootHashResp.Scheme)
+	}
+
+	_, err = client.RootHash(append(filePaths, filepath.Join(dir, "missing.txt")), mt.Scheme{})
+	require.Error(t, err)
+}
+
+func testClientUploadStream(t *testing.T, grpcClient api.MerkleTreeClient) {
+	dir := t.TempDir()
+	var names []string
+	var files [][]byte
+	for idx := 0; idx < 11; idx++ {
+		name, file := fmt.Sprintf("file%02d.txt", idx), []byte(fmt.Sprintf("file content %d", idx))
+		switch idx {
+		case 3:
+			// Large files are streamed in several pieces
+			file = bytes.Repeat([]byte("large file "), 240_000)
+		case 5:
+			file = []byte{}
+		case 7:
+			// Files ending right after a full piece of 1 MiB are completed by an empty piece
+			file = bytes.Repeat([]byte("full piece"), 1<<20/10)
+			file = append(file, make([]byte, 1<<20-len(file))...)
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), file, 0644))
+		names = append(names, name)
+		files = append(files, file)
+	}
+
+	filePaths, err := util.ListFilesFromDir(dir)
+	require.NoError(t, err)
+
+	schemes := []mt.Scheme{
+		{},
+		{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962, ChunkSize: 4096},
+		{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962, ChunkSize: 4096, Chunking: mt.ChunkingContentDefined},
+		{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeMountainRange},
+	}
+	for _, scheme := range schemes {
+		uploadResp, err := client.UploadNamed(grpcClient, names, files, scheme)
+		require.NoError(t, err)
+
+		// Streamed files yield the same roots as the ones uploaded in a single message
+		streamResp, err := client.UploadStream(grpcClient, names, filePaths, scheme)
+		require.NoError(t, err)
+		require.Equal(t, uploadResp.RootHash, streamResp.RootHash)
+		require.Equal(t, uploadResp.SparseRootHash, streamResp.SparseRootHash)
+		require.Equal(t, len(files), streamResp.LeafCount)
+		require.True(t, uploadResp.Scheme.Equal(streamResp.Scheme))
+
+		// The server serves the streamed files with proofs against the streamed root
+		record := &client.RootRecord{
+			RootHash:       streamResp.RootHash,
+			LeafCount:      streamResp.LeafCount,
+			SparseRootHash: streamResp.SparseRootHash,
+			Scheme:         streamResp.Scheme,
+		}
+		for fileIdx, name := range names {
+			getResp, err := client.GetByKey(grpcClient, name, record)
+			require.NoError(t, err)
+			require.Equal(t, fileIdx, getResp.FileIdx)
+			require.True(t, bytes.Equal(files[fileIdx], getResp.File))
+		}
+	}
+
+	// Files streamed without names are not keyed
+	streamResp, err := client.UploadStream(grpcClient, nil, filePaths, mt.Scheme{})
+	require.NoError(t, err)
+	require.Empty(t, streamResp.SparseRootHash)
+	record := &client.RootRecord{RootHash: streamResp.RootHash, LeafCount: streamResp.LeafCount, Scheme: streamResp.Scheme}
+	_, err = client.GetByKey(grpcClient, names[0], record)
+	require.ErrorIs(t, err, mterr.ErrNotKeyed)
+
+	_, err = client.UploadStream(grpcClient, []string{"a.txt", "a.txt"}, filePaths[:2], mt.Scheme{})
+	require.ErrorIs(t, err, mterr.ErrDuplicateFileName)
+	_, err = client.UploadStream(grpcClient, names[:1], filePaths[:2], mt.Scheme{})
+	require.ErrorIs(t, err, mterr.ErrMissingFileNames)
+	_, err = client.UploadStream(grpcClient, nil, nil, mt.Scheme{})
+	require.ErrorIs(t, err, mterr.ErrEmptyFile)
+	_, err = client.UploadStream(grpcClient, nil, []string{filepath.Join(dir, "missing.txt")}, mt.Scheme{})
+	require.Error(t, err)
+
+	// The server refuses streams with an incomplete last file or with names for only some files, and keeps
+	// serving the files uploaded before
+	invalidStreams := map[error][]*api.UploadStreamRequest{
+		mterr.ErrEmptyFile: {
+			{Scheme: &api.Scheme{}, Content: []byte("A"), EndOfFile: true},
+			{Content: []byte("B")},
+		},
+		mterr.ErrMissingFileNames: {
+			{Scheme: &api.Scheme{}, FileName: "a.txt", Content: []byte("A"), EndOfFile: true},
+			{Content: []byte("B"), EndOfFile: true},
+		},
+		mterr.ErrDuplicateFileName: {
+			{Scheme: &api.Scheme{}, FileName: "a.txt", Content: []byte("A"), EndOfFile: true},
+			{FileName: "a.txt", Content: []byte("B"), EndOfFile: true},
+		},
+	}
+	for expectedErr, reqs := range invalidStreams {
+		stream, err := grpcClient.UploadStream(context.Background())
+		require.NoError(t, err)
+		for _, req := range reqs {
+			require.NoError(t, stream.Send(req))
+		}
+		_, err = stream.CloseAndRecv()
+		require.ErrorContains(t, err, expectedErr.Error())
+
+		for fileIdx, file := range files {
+			downloadResp, err := client.Download(grpcClient, fileIdx)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(file, downloadResp.File))
+		}
+	}
+}
+
+// tamperedChunkClient: mimics a malicious server that flips the first byte of every chunk it sends
+type tamperedChunkClient struct {
+	api.MerkleTreeClient
+}
+
+func (c *tamperedChunkClient) DownloadChunk(ctx context.Context, in *api.DownloadChunkRequest, opts ...grpc.CallOption) (*api.DownloadChunkResponse, error) {
+	resp, err := c.MerkleTreeClient.DownloadChunk(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	resp.ChunkContent = append([]byte(nil), resp.ChunkContent...)
+	resp.ChunkContent[0] ^= 0xff
+	return resp, nil
+}
+
+func testClientDownloadChunk(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"),
+		[]byte("the quick brown fox jumps over the lazy dog"),
+		[]byte("0123456789abcdef"),
+	}
+
+	for _, chunkSize := range []int{0, 4, 5} {
+		scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962, ChunkSize: chunkSize}
+		uploadResp, err := client.Upload(grpcClient, files, scheme)
+		require.NoError(t, err)
+		record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+
+		for fileIdx, file := range files {
+			chunkCount := len(mt.Chunks(file, mt.WithChunkSize(chunkSize)))
+			var joined []byte
+			for chunkIdx := 0; chunkIdx < chunkCount; chunkIdx++ {
+				chunkResp, err := client.DownloadChunk(grpcClient, fileIdx, chunkIdx, record)
+				require.NoError(t, err)
+				require.Equal(t, chunkCount, chunkResp.ChunkCount)
+				joined = append(joined, chunkResp.Chunk...)
+			}
+			require.Equal(t, file, joined)
+
+			_, err = client.DownloadChunk(grpcClient, fileIdx, chunkCount, record)
+			require.Error(t, err)
+		}
+
+		// Byte ranges spanning one or several chunks, cut short at the end of the file
+		file := files[1]
+		for _, r := range [][2]int{{0, 0}, {3, 3}, {2, 13}, {10, 41}, {40, 100}} {
+			bytesResp, err := client.DownloadBytes(grpcClient, 1, r[0], r[1], record)
+			require.NoError(t, err)
+			require.Equal(t, file[r[0]:min(r[1]+1, len(file))], bytesResp.Bytes)
+		}
+
+		_, err = client.DownloadBytes(grpcClient, 1, len(file), len(file)+5, record)
+		require.ErrorIs(t, err, mterr.ErrInvalidRange)
+		_, err = client.DownloadBytes(grpcClient, 1, 5, 2, record)
+		require.ErrorIs(t, err, mterr.ErrInvalidRange)
+
+		// Chunks altered by the server are refused
+		_, err = client.DownloadBytes(&tamperedChunkClient{grpcClient}, 1, 2, 13, record)
+		require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+
+		// The server serves the chunks of a replaced file rather than the ones of the file it cached before
+		replaced := []byte("pack my box with five dozen liquor jugs")
+		replaceResp, err := client.ReplaceFile(grpcClient, 1, "", replaced, record)
+		require.NoError(t, err)
+		record.RootHash = replaceResp.RootHash
+		bytesResp, err := client.DownloadBytes(grpcClient, 1, 0, len(replaced)-1, record)
+		require.NoError(t, err)
+		require.Equal(t, replaced, bytesResp.Bytes)
+	}
+}
+
+// countingChunksClient: records the number of chunks the client sends along with its deduplicated uploads
+type countingChunksClient struct {
+	api.MerkleTreeClient
+	sent []int
+}
+
+func (c *countingChunksClient) UploadChunks(ctx context.Context, in *api.UploadChunksRequest, opts ...grpc.CallOption) (*api.UploadResponse, error) {
+	c.sent = append(c.sent, len(in.Chunks))
+	return c.MerkleTreeClient.UploadChunks(ctx, in, opts...)
+}
+
+func testClientDeduplicatedUpload(t *testing.T, grpcClient api.MerkleTreeClient) {
+	const chunkSize = 1024
+	random := rand.New(rand.NewSource(1))
+	names := []string{"a.bin", "b.bin", "c.bin"}
+	files := make([][]byte, len(names))
+	for idx := range files {
+		files[idx] = make([]byte, 32*chunkSize)
+		random.Read(files[idx])
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962, ChunkSize: chunkSize, Chunking: mt.ChunkingContentDefined}
+
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+	chunkCount := 0
+	for _, file := range files {
+		chunkCount += len(mt.Chunks(file, opts...))
+	}
+
+	// The first snapshot transfers every chunk
+	counting := &countingChunksClient{MerkleTreeClient: grpcClient}
+	uploadResp, err := client.UploadDeduplicated(counting, names, files, scheme)
+	require.NoError(t, err)
+	require.Equal(t, []int{chunkCount}, counting.sent)
+
+	namedResp, err := client.UploadNamed(grpcClient, names, files, scheme)
+	require.NoError(t, err)
+	require.Equal(t, namedResp.RootHash, uploadResp.RootHash)
+	require.Equal(t, namedResp.SparseRootHash, uploadResp.SparseRootHash)
+
+	// The next snapshot only transfers the chunks around an edit of a single file
+	snapshot := append([][]byte(nil), files...)
+	snapshot[1] = append(append(append([]byte(nil), files[1][:10000]...), "edited"...), files[1][10000:]...)
+	uploadResp, err = client.UploadDeduplicated(counting, names, snapshot, scheme)
+	require.NoError(t, err)
+	require.LessOrEqual(t, counting.sent[1], 2)
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+
+	for fileIdx, file := range snapshot {
+		downloadResp, err := client.Download(grpcClient, fileIdx)
+		require.NoError(t, err)
+		require.Equal(t, file, downloadResp.File)
+	}
+
+	bytesResp, err := client.DownloadBytes(grpcClient, 1, 9000, 20000, record)
+	require.NoError(t, err)
+	require.Equal(t, snapshot[1][9000:20001], bytesResp.Bytes)
+
+	// Chunks released by the replaced snapshot are transferred again
+	_, err = client.UploadDeduplicated(counting, names, files, scheme)
+	require.NoError(t, err)
+	require.Positive(t, counting.sent[2])
+
+	// Manifests referencing chunks that are neither stored nor sent along are refused
+	_, err = grpcClient.UploadChunks(context.Background(), &api.UploadChunksRequest{
+		Manifests: []*api.FileManifest{{ChunkKeys: []string{mt.ChunkKey([]byte("unknown"))}}},
+		Scheme:    util.ToAPIScheme(scheme),
+	})
+	require.ErrorContains(t, err, mterr.ErrChunkNotFound.Error())
+}
+
+func testClientCompactProof(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"),
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA3_256, DomainSeparation: true, Version: mt.VersionBinary}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+
+	for fileIdx, file := range files {
+		proofResp, err := client.GetMerkleProof(grpcClient, fileIdx)
+		require.NoError(t, err)
+		require.NoError(t, proofResp.Compact(fileIdx, uploadResp.LeafCount))
+		require.Nil(t, proofResp.Proofs)
+
+		// The compact proof survives the JSON round trip through the proofs file as base64
+		proofJSON, err := json.Marshal(proofResp)
+		require.NoError(t, err)
+		var stored client.ProofResponse
+		require.NoError(t, json.Unmarshal(proofJSON, &stored))
+
+		verifyReq := client.VerifyRequest{
+			RootHash:  []byte(uploadResp.RootHash),
+			LeafCount: uploadResp.LeafCount,
+			FileIdx:   fileIdx,
+			File:      file,
+			Proof:     stored.Proof,
+			Scheme:    scheme,
+		}
+		_, err = client.VerifyMerkleProofLocally(verifyReq)
+		require.NoError(t, err)
+
+		// A compact proof claiming another file index or tree size is refused
+		verifyReq.FileIdx = (fileIdx + 1) % len(files)
+		_, err = client.VerifyMerkleProofLocally(verifyReq)
+		require.ErrorIs(t, err, mterr.ErrInvalidProof)
+
+		verifyReq.FileIdx, verifyReq.LeafCount = fileIdx, uploadResp.LeafCount+1
+		_, err = client.VerifyMerkleProofLocally(verifyReq)
+		require.ErrorIs(t, err, mterr.ErrInvalidProof)
+	}
+}
+
+func testClientProofReplay(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"),
+	}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+
+	proofResp, err := client.GetMerkleProof(grpcClient, 2)
+	require.NoError(t, err)
+
+	// The proof for file2 is refused for file3, also when its node indices are rewritten to cover file3
+	forged := make([]*api.TreeNode, len(proofResp.Proofs))
+	for idx, proof := range proofResp.Proofs {
+		forged[idx] = &api.TreeNode{Hash: proof.Hash, LeftIdx: proof.RightIdx, RightIdx: proof.LeftIdx}
+	}
+
+	for _, proofs := range [][]*api.TreeNode{proofResp.Proofs, forged} {
+		verifyReq := client.VerifyRequest{
+			RootHash:  []byte(uploadResp.RootHash),
+			LeafCount: uploadResp.LeafCount,
+			FileIdx:   3,
+			File:      files[3],
+			Proofs:    proofs,
+			Scheme:    scheme,
+		}
+
+		_, err = client.VerifyMerkleProofLocally(verifyReq)
+		require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+
+		_, err = client.VerifyMerkleProof(grpcClient, verifyReq)
+		require.ErrorIs(t, err, mterr.ErrMerkleVerificationFail)
+	}
+
+	// A proof missing a sibling is rejected by the server
+	_, err = client.VerifyMerkleProof(grpcClient, client.VerifyRequest{
+		RootHash: []byte(uploadResp.RootHash),
+		FileIdx:  2,
+		File:     files[2],
+		Proofs:   proofResp.Proofs[:1],
+		Scheme:   scheme,
+	})
+	require.Error(t, err)
+}
+
+func testClientMountainRange(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"), []byte("F"), []byte("G"),
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA256, DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeMountainRange}
+	rfcScheme := scheme
+	rfcScheme.Shape = mt.ShapeRFC6962
+
+	rfcResp, err := client.Upload(grpcClient, files, rfcScheme)
+	require.NoError(t, err)
+
+	// The dataset is stored as a mountain range, which yields the same root hash as the RFC 6962 tree
+	uploadResp, err := client.Upload(grpcClient, files[:3], scheme)
+	require.NoError(t, err)
+	require.Equal(t, scheme, uploadResp.Scheme)
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+
+	for _, file := range files[3:] {
+		appendResp, err := client.AppendFiles(grpcClient, nil, [][]byte{file}, record)
+		require.NoError(t, err)
+
+		consistencyResp, err := client.VerifyConsistency(grpcClient, record)
+		require.NoError(t, err)
+		require.Equal(t, appendResp.RootHash, consistencyResp.RootHash)
+		record = &client.RootRecord{RootHash: appendResp.RootHash, LeafCount: appendResp.LeafCount, Scheme: appendResp.Scheme}
+	}
+	require.Equal(t, rfcResp.RootHash, record.RootHash)
+
+	for fileIdx, file := range files {
+		proofResp, err := client.GetMerkleProof(grpcClient, fileIdx)
+		require.NoError(t, err)
+
+		_, err = client.VerifyMerkleProofLocally(client.VerifyRequest{
+			RootHash:  []byte(record.RootHash),
+			LeafCount: record.LeafCount,
+			FileIdx:   fileIdx,
+			File:      file,
+			Proofs:    proofResp.Proofs,
+			Scheme:    record.Scheme,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func testClientDiff(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := make([][]byte, 64)
+	for idx := range files {
+		files[idx] = []byte(fmt.Sprintf("file %d", idx))
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA256, DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	_, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+
+	// Nothing but the root is requested for an unchanged directory
+	diffResp, err := client.Diff(grpcClient, files, scheme)
+	require.NoError(t, err)
+	require.Empty(t, diffResp.FileIdxs)
+	require.Equal(t, 1, diffResp.RequestedNodes)
+
+	local := make([][]byte, len(files))
+	copy(local, files)
+	local[5] = []byte("changed file 5")
+	local[42] = []byte("changed file 42")
+
+	// Only the nodes on the paths to the changed files and their siblings are requested
+	diffResp, err = client.Diff(grpcClient, local, scheme)
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 42}, diffResp.FileIdxs)
+	require.LessOrEqual(t, diffResp.RequestedNodes, 1+2*2*6)
+
+	// The local files have to be hashed like the server's and cover as many files
+	_, err = client.Diff(grpcClient, local[:63], scheme)
+	require.ErrorIs(t, err, mterr.ErrLeafCountMisMatch)
+	midpointScheme := scheme
+	midpointScheme.Shape = mt.ShapeMidpoint
+	_, err = client.Diff(grpcClient, local, midpointScheme)
+	require.ErrorIs(t, err, mterr.ErrSchemeMisMatch)
+}
+
+func testClientFetchTree(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := make([][]byte, 21)
+	for idx := range files {
+		files[idx] = []byte(fmt.Sprintf("file %d", idx))
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA256, DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	_, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+
+	// The server's tree exports like the local one, also when cut off at a depth with a highlighted proof path
+	for _, exportOpts := range [][]mt.ExportOption{nil, {mt.WithMaxDepth(2), mt.WithProofPath(13)}} {
+		root, err := client.FetchTree(grpcClient, exportOpts...)
+		require.NoError(t, err)
+
+		for _, format := range mt.ExportFormats() {
+			var expected, actual bytes.Buffer
+			require.NoError(t, merkleTree.Export(&expected, mt.ExportFormat(format), exportOpts...))
+			require.NoError(t, mt.Export(&actual, root, mt.ExportFormat(format), exportOpts...))
+			require.Equal(t, expected.String(), actual.String())
+		}
+	}
+
+	_, err = client.FetchTree(grpcClient, mt.WithProofPath(len(files)))
+	require.ErrorIs(t, err, mterr.ErrIndexOutOfBound)
+}
+
+func testClientBitcoinShape(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := [][]byte{
+		[]byte("A"), []byte("B"), []byte("C"), []byte("D"), []byte("E"),
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA256, Version: mt.VersionBinary, Shape: mt.ShapeBitcoin}
+
+	// The root hash pairs E with itself on every level up to the root
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+	require.Equal(t, scheme, uploadResp.Scheme)
+
+	hash := func(data ...[]byte) []byte {
+		return mt.DefaultHasher().Sum(bytes.Join(data, nil))
+	}
+	ee := hash(hash(files[4]), hash(files[4]))
+	abcd := hash(hash(hash(files[0]), hash(files[1])), hash(hash(files[2]), hash(files[3])))
+	require.Equal(t, mt.EncodeHash(hash(abcd, hash(ee, ee))), uploadResp.RootHash)
+
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+	appendResp, err := client.AppendFiles(grpcClient, nil, [][]byte{[]byte("F")}, record)
+	require.NoError(t, err)
+	record = &client.RootRecord{RootHash: appendResp.RootHash, LeafCount: appendResp.LeafCount, Scheme: appendResp.Scheme}
+
+	for fileIdx, file := range append(files, []byte("F")) {
+		proofResp, err := client.GetMerkleProof(grpcClient, fileIdx)
+		require.NoError(t, err)
+
+		_, err = client.VerifyMerkleProofLocally(client.VerifyRequest{
+			RootHash:  []byte(record.RootHash),
+			LeafCount: record.LeafCount,
+			FileIdx:   fileIdx,
+			File:      file,
+			Proofs:    proofResp.Proofs,
+			Scheme:    record.Scheme,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func testClientTreeInfo(t *testing.T, grpcClient api.MerkleTreeClient) {
+	files := make([][]byte, 13)
+	for idx := range files {
+		files[idx] = []byte(fmt.Sprintf("file %d", idx))
+	}
+	scheme := mt.Scheme{HashAlgorithm: mt.SHA3_256, DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	uploadResp, err := client.Upload(grpcClient, files, scheme)
+	require.NoError(t, err)
+
+	// The statistics match the locally built tree apart from the time it took to build
+	opts, err := scheme.Options()
+	require.NoError(t, err)
+	merkleTree, err := mt.BuildMerkleTree(files, opts...)
+	require.NoError(t, err)
+
+	infoResp, err := client.GetTreeInfo(grpcClient)
+	require.NoError(t, err)
+	require.Equal(t, uploadResp.RootHash, infoResp.RootHash)
+	require.Equal(t, scheme, infoResp.Scheme)
+	require.Positive(t, infoResp.Stats.BuildDuration)
+
+	expected := merkleTree.Stats()
+	expected.BuildDuration = infoResp.Stats.BuildDuration
+	require.Equal(t, expected, infoResp.Stats)
+
+	// Appending a file adds the new leaf and its parent
+	record := &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+	_, err = client.AppendFiles(grpcClient, nil, [][]byte{[]byte("file 13")}, record)
+	require.NoError(t, err)
+
+	infoResp, err = client.GetTreeInfo(grpcClient)
+	require.NoError(t, err)
+	require.Equal(t, len(files)+1, infoResp.Stats.LeafCount)
+	require.Equal(t, expected.NodeCount+2, infoResp.Stats.NodeCount)
+}
@@ -1,8 +1,18 @@
 package test
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/srinathln7/merkle_gaurd/internal/client"
+	"github.com/srinathln7/merkle_gaurd/internal/server"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/srinathln7/merkle_gaurd/api/v1/proto"
+	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
 )
 
 // Run the tests
@@ -34,4 +44,164 @@ func TestGRPCServer(t *testing.T) {
 	t.Run("merkle root mis-match", func(t *testing.T) {
 		testClientMerkleRootMisMatch(t, grpcClient)
 	})
+
+	t.Run("client computes the merkle root locally on upload", func(t *testing.T) {
+		testClientUploadLocalMerkleRoot(t, grpcClient)
+	})
+
+	t.Run("merkle verification with a configurable scheme", func(t *testing.T) {
+		testClientMerkleVerificationWithScheme(t, grpcClient)
+	})
+
+	t.Run("append files to the uploaded ones", func(t *testing.T) {
+		testClientAppendFiles(t, grpcClient)
+	})
+
+	t.Run("consistency proof between two tree sizes", func(t *testing.T) {
+		testClientConsistencyProof(t, grpcClient)
+	})
+
+	t.Run("replace a single file", func(t *testing.T) {
+		testClientReplaceFile(t, grpcClient)
+	})
+
+	t.Run("multi-proof for several files", func(t *testing.T) {
+		testClientMultiProof(t, grpcClient)
+	})
+
+	t.Run("download a range of files", func(t *testing.T) {
+		testClientDownloadRange(t, grpcClient)
+	})
+
+	t.Run("files keyed by name in a sparse merkle tree", func(t *testing.T) {
+		testClientKeyedFiles(t, grpcClient)
+	})
+
+	t.Run("merkle root hash of a directory streamed file by file", func(t *testing.T) {
+		testClientRootHash(t, grpcClient)
+	})
+
+	t.Run("upload files streamed one at a time", func(t *testing.T) {
+		testClientUploadStream(t, grpcClient)
+	})
+
+	t.Run("download and verify single chunks and byte ranges of a file", func(t *testing.T) {
+		testClientDownloadChunk(t, grpcClient)
+	})
+
+	t.Run("deduplicated upload of content-defined chunks", func(t *testing.T) {
+		testClientDeduplicatedUpload(t, grpcClient)
+	})
+
+	t.Run("compact merkle proofs stored as base64", func(t *testing.T) {
+		testClientCompactProof(t, grpcClient)
+	})
+
+	t.Run("proofs are bound to the claimed file index", func(t *testing.T) {
+		testClientProofReplay(t, grpcClient)
+	})
+
+	t.Run("append to a merkle mountain range", func(t *testing.T) {
+		testClientMountainRange(t, grpcClient)
+	})
+
+	t.Run("diff local files against the server's merkle tree", func(t *testing.T) {
+		testClientDiff(t, grpcClient)
+	})
+
+	t.Run("export the server's merkle tree", func(t *testing.T) {
+		testClientFetchTree(t, grpcClient)
+	})
+
+	t.Run("bitcoin shaped merkle tree", func(t *testing.T) {
+		testClientBitcoinShape(t, grpcClient)
+	})
+
+	t.Run("merkle tree info", func(t *testing.T) {
+		testClientTreeInfo(t, grpcClient)
+	})
+}
+
+func TestServerState(t *testing.T) {
+	// Every server started in this test persists its state to the same directory, so a server started after
+	// another one was stopped resumes with its files and merkle trees
+	stateDir := t.TempDir()
+	t.Setenv("STATE_DIR", stateDir)
+
+	names := []string{"a.txt", "b.txt", "c.txt"}
+	files := [][]byte{[]byte("A"), []byte("B"), []byte("C")}
+	scheme := mt.Scheme{DomainSeparation: true, Version: mt.VersionBinary, Shape: mt.ShapeRFC6962}
+
+	grpcClient, teardown := SetupGRPCClient(t, nil)
+	uploadResp, err := client.UploadNamed(grpcClient, names, files, scheme)
+	require.NoError(t, err)
+	record := &client.RootRecord{
+		RootHash:       uploadResp.RootHash,
+		LeafCount:      uploadResp.LeafCount,
+		SparseRootHash: uploadResp.SparseRootHash,
+		Scheme:         uploadResp.Scheme,
+	}
+	appendResp, err := client.AppendFiles(grpcClient, []string{"d.txt"}, [][]byte{[]byte("D")}, record)
+	require.NoError(t, err)
+	record = &client.RootRecord{
+		RootHash:       appendResp.RootHash,
+		LeafCount:      appendResp.LeafCount,
+		SparseRootHash: appendResp.SparseRootHash,
+		Scheme:         appendResp.Scheme,
+	}
+	names, files = append(names, "d.txt"), append(files, []byte("D"))
+	teardown()
+
+	// The persisted merkle tree can be inspected offline through its binary encoding
+	treeFile, err := os.Open(filepath.Join(stateDir, "merkle.tree"))
+	require.NoError(t, err)
+	merkleTree, err := mt.ReadMerkleTree(treeFile)
+	require.NoError(t, treeFile.Close())
+	require.NoError(t, err)
+	require.Equal(t, record.RootHash, mt.EncodeHash(merkleTree.GetMerkleRoot().Hash))
+	require.Equal(t, record.LeafCount, merkleTree.LeafCount())
+
+	// A restarted server proves the files against the roots the client recorded before the restart
+	grpcClient, teardown = SetupGRPCClient(t, nil)
+	rangeResp, err := client.DownloadRange(grpcClient, 0, len(files)-1, record)
+	require.NoError(t, err)
+	require.Equal(t, files, rangeResp.Files)
+	for fileIdx, name := range names {
+		getResp, err := client.GetByKey(grpcClient, name, record)
+		require.NoError(t, err)
+		require.Equal(t, fileIdx, getResp.FileIdx)
+		require.Equal(t, files[fileIdx], getResp.File)
+	}
+	_, err = client.VerifyConsistency(grpcClient, record)
+	require.NoError(t, err)
+
+	replaceResp, err := client.ReplaceFile(grpcClient, 1, "b.txt", []byte("B2"), record)
+	require.NoError(t, err)
+	record.RootHash, record.SparseRootHash = replaceResp.RootHash, replaceResp.SparseRootHash
+	teardown()
+
+	// Replaced files survive a restart as well
+	grpcClient, teardown = SetupGRPCClient(t, nil)
+	getResp, err := client.GetByKey(grpcClient, "b.txt", record)
+	require.NoError(t, err)
+	require.Equal(t, []byte("B2"), getResp.File)
+
+	// Files uploaded without names replace the named ones and cannot be fetched by name after a restart
+	uploadResp, err = client.Upload(grpcClient, [][]byte{[]byte("X"), []byte("Y")}, scheme)
+	require.NoError(t, err)
+	teardown()
+
+	grpcClient, teardown = SetupGRPCClient(t, nil)
+	defer teardown()
+	record = &client.RootRecord{RootHash: uploadResp.RootHash, LeafCount: uploadResp.LeafCount, Scheme: uploadResp.Scheme}
+	rangeResp, err = client.DownloadRange(grpcClient, 0, 1, record)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("X"), []byte("Y")}, rangeResp.Files)
+	_, err = grpcClient.GetByKey(context.Background(), &api.KeyRequest{FileName: "a.txt"})
+	require.ErrorContains(t, err, mterr.ErrNotKeyed.Error())
+
+	// A state directory whose names do not match the merkle tree is refused on startup
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, "names.json"), []byte(`["a.txt"]`), 0644))
+	_, err = server.NewgrpcServer()
+	require.ErrorIs(t, err, mterr.ErrLeafCountMisMatch)
 }
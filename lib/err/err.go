@@ -12,4 +12,31 @@ var (
 	ErrMerkleVerificationFail = errors.New("merkle tree verification failed")
 	ErrLeafDoesNotExist       = errors.New("leaf node (file) does not exist")
 	ErrConversion             = errors.New("type conversion not successful")
+	ErrInvalidProof           = errors.New("merkle proof does not match the tree shape")
+	ErrUnknownHashAlgorithm   = errors.New("unknown hash algorithm")
+	ErrSchemeMisMatch         = errors.New("merkle tree scheme mis-match")
+	ErrUnknownSchemeVersion   = errors.New("unknown merkle tree scheme version")
+	ErrInvalidHash            = errors.New("hash is not a valid hexadecimal digest")
+	ErrUnknownTreeShape       = errors.New("unknown merkle tree shape")
+	ErrUnknownChunking        = errors.New("unknown chunking")
+	ErrUnsupportedLayout      = errors.New("merkle tree layout does not support the tree shape")
+	ErrLeafCountMisMatch      = errors.New("merkle tree leaf count mis-match")
+	ErrUnsupportedShape       = errors.New("operation not supported for the merkle tree shape")
+	ErrInvalidTreeSize        = errors.New("invalid merkle tree size")
+	ErrEmptyIndices           = errors.New("no file(leaf) indices passed")
+	ErrInvalidIndices         = errors.New("file(leaf) indices must be a comma separated list of indices and ranges like 0,3,10-20")
+	ErrMissingFileNames       = errors.New("every file needs a file name")
+	ErrDuplicateFileName      = errors.New("file name is not unique")
+	ErrFileNameNotFound       = errors.New("no file stored under the file name")
+	ErrFileNameExists         = errors.New("a file is stored under the file name")
+	ErrInvalidFileName        = errors.New("file name must be a path inside the upload directory")
+	ErrInvalidChunkSize       = errors.New("chunk size must not be negative")
+	ErrInvalidRange           = errors.New("byte range must be an inclusive range of offsets like 0-1023")
+	ErrNotKeyed               = errors.New("files were uploaded without file names")
+	ErrChunkNotFound          = errors.New("chunk is neither stored on the server nor part of the upload")
+	ErrInvalidTreeEncoding    = errors.New("data is not an encoded merkle tree")
+	ErrUnsupportedEncoding    = errors.New("unsupported merkle tree encoding version")
+	ErrChecksumMisMatch       = errors.New("merkle tree encoding checksum mis-match")
+	ErrInvalidProofEncoding   = errors.New("data is not an encoded merkle proof")
+	ErrUnknownExportFormat    = errors.New("unknown merkle tree export format")
 )
@@ -1,11 +1,18 @@
 package util
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
+
+	api "github.com/srinathln7/merkle_gaurd/api/v1/proto"
+	mt "github.com/srinathln7/merkle_gaurd/internal/merkle"
+	mterr "github.com/srinathln7/merkle_gaurd/lib/err"
 )
 
 func ServerLog(msg string) {
@@ -21,28 +28,92 @@ func ErrLog(msg string) {
 }
 
 func ReadFilesFromDir(dir string) ([][]byte, error) {
+	_, fileContents, err := ReadNamedFilesFromDir(dir)
+	return fileContents, err
+}
+
+// ReadNamedFilesFromDir reads the files of the directory like `ReadFilesFromDir` and additionally
+// returns their names, which key the files in the sparse merkle tree (see `FileKey`).
+func ReadNamedFilesFromDir(dir string) ([]string, [][]byte, error) {
+	names, err := readableFiles(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fileNames []string
+	var fileContents [][]byte
+	for _, name := range names {
+		filePath := filepath.Join(dir, name)
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Printf("Failed to read file %s: %v", filePath, err)
+			continue
+		}
+
+		fileName, err := FileKey(dir, filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileNames = append(fileNames, fileName)
+		fileContents = append(fileContents, content)
+	}
+
+	return fileNames, fileContents, nil
+}
+
+// ListFilesFromDir returns the paths of the files of the directory in the order `ReadFilesFromDir` reads them,
+// which lets large directories be processed one file at a time. It skips the same files `ReadFilesFromDir` skips.
+func ListFilesFromDir(dir string) ([]string, error) {
+	names, err := readableFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var filePaths []string
+	for _, name := range names {
+		filePaths = append(filePaths, filepath.Join(dir, name))
+	}
+	return filePaths, nil
+}
+
+// FileKey returns the name the file at `filePath` is keyed by in the sparse merkle tree when the directory
+// `dir` is uploaded: its path relative to the directory with forward slashes. Files therefore keep their keys
+// wherever the directory is uploaded from and on every operating system, and files of the same name in
+// different subdirectories get different keys. Paths outside the directory are refused.
+func FileKey(dir, filePath string) (string, error) {
+	rel, err := filepath.Rel(dir, filePath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", mterr.ErrInvalidFileName, filePath)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// readableFiles returns the names of the files of the directory that can be opened for reading, ordered by name.
+// Subdirectories and files that cannot be opened, e.g. for missing permissions or as dangling symlinks, are skipped,
+// so that listing and reading a directory yield the same files.
+func readableFiles(dir string) ([]string, error) {
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
 
-	var fileContents [][]byte
+	var names []string
 	for _, file := range files {
 		if file.IsDir() {
 			continue
 		}
 
 		filePath := filepath.Join(dir, file.Name())
-		content, err := os.ReadFile(filePath)
+		f, err := os.Open(filePath)
 		if err != nil {
 			log.Printf("Failed to read file %s: %v", filePath, err)
 			continue
 		}
+		f.Close()
 
-		fileContents = append(fileContents, content)
+		names = append(names, file.Name())
 	}
-
-	return fileContents, nil
+	return names, nil
 }
 
 func WriteFile(directory, fileName, content string) error {
@@ -70,3 +141,85 @@ func WriteFile(directory, fileName, content string) error {
 
 	return nil
 }
+
+// MaxIndices is the largest number of indices `ParseIndices` expands a list into if the number of files is unknown.
+const MaxIndices = 1 << 16
+
+// ParseIndices parses a comma separated list of file indices and inclusive index ranges, e.g. `0,3,10-20`.
+// Every index has to be below `leafCount`, the number of files the indices refer to. If it is unknown and
+// passed as 0, the list may expand into at most `MaxIndices` indices. Ranges are checked before they are
+// expanded, so a range like `0-9999999999` is refused instead of exhausting the memory.
+func ParseIndices(s string, leafCount int) ([]int, error) {
+	var idxs []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		from, to, isRange := strings.Cut(part, "-")
+
+		l, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil || l < 0 {
+			return nil, mterr.ErrInvalidIndices
+		}
+
+		r := l
+		if isRange {
+			if r, err = strconv.Atoi(strings.TrimSpace(to)); err != nil || r < l {
+				return nil, mterr.ErrInvalidIndices
+			}
+		}
+
+		switch {
+		case leafCount > 0 && r >= leafCount:
+			return nil, fmt.Errorf("%w: index %d is out of range for %d files", mterr.ErrInvalidIndices, r, leafCount)
+		case leafCount <= 0 && r-l >= MaxIndices-len(idxs):
+			return nil, fmt.Errorf("%w: more than %d indices", mterr.ErrInvalidIndices, MaxIndices)
+		}
+
+		for idx := l; idx <= r; idx++ {
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs, nil
+}
+
+// ParseRange parses an inclusive byte range like `0-1023`.
+func ParseRange(s string) (from, to int, err error) {
+	l, r, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, mterr.ErrInvalidRange
+	}
+
+	from, err = strconv.Atoi(strings.TrimSpace(l))
+	if err != nil || from < 0 {
+		return 0, 0, mterr.ErrInvalidRange
+	}
+
+	to, err = strconv.Atoi(strings.TrimSpace(r))
+	if err != nil || to < from {
+		return 0, 0, mterr.ErrInvalidRange
+	}
+	return from, to, nil
+}
+
+// ToMerkleScheme converts the scheme received over gRPC. A missing scheme selects the defaults.
+func ToMerkleScheme(scheme *api.Scheme) mt.Scheme {
+	return mt.Scheme{
+		HashAlgorithm:    scheme.GetHashAlgorithm(),
+		DomainSeparation: scheme.GetDomainSeparation(),
+		Version:          mt.Version(scheme.GetVersion()),
+		Shape:            mt.Shape(scheme.GetShape()),
+		ChunkSize:        int(scheme.GetChunkSize()),
+		Chunking:         mt.Chunking(scheme.GetChunking()),
+	}
+}
+
+// ToAPIScheme converts the scheme of a merkle tree to its gRPC representation.
+func ToAPIScheme(scheme mt.Scheme) *api.Scheme {
+	return &api.Scheme{
+		HashAlgorithm:    scheme.HashAlgorithm,
+		DomainSeparation: scheme.DomainSeparation,
+		Version:          int32(scheme.Version),
+		Shape:            int32(scheme.Shape),
+		ChunkSize:        int64(scheme.ChunkSize),
+		Chunking:         int32(scheme.Chunking),
+	}
+}